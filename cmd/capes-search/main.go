@@ -2,64 +2,220 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	stderrors "errors" // standard library errors for As function
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/alexandreffaria/reviu/internal/browser"
 	"github.com/alexandreffaria/reviu/internal/cli"
 	"github.com/alexandreffaria/reviu/internal/config"
 	"github.com/alexandreffaria/reviu/internal/errors"
+	"github.com/alexandreffaria/reviu/internal/i18n"
 	"github.com/alexandreffaria/reviu/internal/logger"
 	"github.com/alexandreffaria/reviu/internal/result"
 	"github.com/alexandreffaria/reviu/internal/search"
+	"github.com/alexandreffaria/reviu/internal/version"
 )
 
 func main() {
+	// Parse command-line flags first, since they decide where logs go
+	params := config.SetupFlags(nil)
+
+	// Report the build and stop before anything else runs
+	if params.ShowVersion {
+		fmt.Println(version.String())
+		return
+	}
+
+	// Describe the flags for external tooling and stop
+	if params.ListFlagsJSON {
+		if err := config.WriteFlagsJSON(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list flags: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Initialize logger
-	log := logger.NewLogger(logger.WithLevel(logger.INFO))
+	// When a JSON summary is requested, stdout is reserved for it
+	logOutput := os.Stdout
+	if params.JSONSummary {
+		logOutput = os.Stderr
+	}
+	logFormat := logger.Text
+	if params.LogFormat == "json" {
+		logFormat = logger.JSON
+	}
+	log := logger.NewLogger(logger.WithLevel(logger.INFO), logger.WithWriter(logOutput), logger.WithFormat(logFormat))
+
+	// Messages printed to the user follow the selected locale
+	locale := i18n.Locale(params.Locale)
+
+	// Keep a copy of the logs in a file, next to the console output
+	if params.LogFile != "" {
+		fileLog, err := logger.FileLogger(params.LogFile, logger.WithFormat(logFormat))
+		if err != nil {
+			err = errors.NewConfigError("invalid -log-file", err)
+			os.Exit(reportError(log, locale, err, params.ErrorJSON))
+		}
+		log = logger.MultiLogger(log, fileLog)
+	}
+
+	// An invalid level keeps INFO here and is reported by the validator
+	if level, err := logger.ParseLevel(params.LogLevel); err == nil {
+		log.SetLevel(level)
+	}
 	log.Info("Starting CAPES Search Tool")
 
-	// Run the application and handle errors
-	if err := run(log); err != nil {
-		// Determine error handling based on error type
-		var appErr *errors.AppError
-		if stderrors.As(err, &appErr) {
-			switch appErr.Type {
-			case errors.Configuration:
-				log.Error("Configuration error: %v", err)
-				fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
-				os.Exit(1)
-
-			case errors.UserInput:
-				log.Error("Input error: %v", err)
-				fmt.Fprintf(os.Stderr, "Input error: %v\n", err)
-				// Show usage information
-				os.Exit(2)
-
-			case errors.Browser:
-				log.Error("Browser error: %v", err)
-				fmt.Fprintf(os.Stderr, "Browser error: %v\n", err)
-				os.Exit(3)
-
-			default:
-				log.Error("Application error: %v", err)
-				fmt.Fprintf(os.Stderr, "Application error: %v\n", err)
-				os.Exit(1)
-			}
-		} else {
-			log.Error("Unexpected error: %v", err)
-			fmt.Fprintf(os.Stderr, "Unexpected error: %v\n", err)
-			os.Exit(1)
+	// os.Exit skips deferred calls, so every exit path closes the log file first
+	exit := func(code int) {
+		closeLog(log)
+		os.Exit(code)
+	}
+
+	// Don't leave Chromium running if the run is interrupted or crashes
+	ctx := interruptContext(log)
+	defer func() {
+		if r := recover(); r != nil {
+			browser.KillLaunchedBrowsers()
+			panic(r)
 		}
+	}()
+
+	// Run the application and handle errors
+	if err := run(ctx, log, params); err != nil {
+		exit(reportError(log, locale, err, params.ErrorJSON))
+	}
+
+	// An interrupted run that still saved its results exits like Ctrl+C would
+	if ctx.Err() != nil {
+		log.Warn("Run interrupted")
+		exit(130)
 	}
 
 	log.Info("Application completed successfully")
+	closeLog(log)
+}
+
+// errorReport is a failed run as printed by -error-json
+type errorReport struct {
+	Type    string `json:"type"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// reportError logs err and prints it to stderr, as a JSON errorReport when
+// asJSON is set, and returns the exit code for the type of err
+func reportError(log logger.Logger, locale i18n.Locale, err error, asJSON bool) int {
+	errType := errors.Unknown
+	label, messageKey, exitCode := "Unexpected error", "error.unexpected", 1
+
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		errType = appErr.Type
+		switch appErr.Type {
+		case errors.Configuration:
+			label, messageKey, exitCode = "Configuration error", "error.config", 1
+		case errors.UserInput:
+			label, messageKey, exitCode = "Input error", "error.input", 2
+		case errors.Browser:
+			label, messageKey, exitCode = "Browser error", "error.browser", 3
+		case errors.Network:
+			label, messageKey, exitCode = "Network error", "error.network", 1
+		case errors.EmptyResult:
+			label, messageKey, exitCode = "Export skipped", "error.emptyResults", 4
+		default:
+			label, messageKey, exitCode = "Application error", "error.application", 1
+		}
+	}
+
+	log.Error("%s: %v", label, err)
+	if !asJSON {
+		fmt.Fprintln(os.Stderr, i18n.Message(locale, messageKey, err))
+		return exitCode
+	}
+
+	encoder := json.NewEncoder(os.Stderr)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(errorReport{
+		Type:    errType.String(),
+		Code:    string(errors.Code(err)),
+		Message: err.Error(),
+	})
+	return exitCode
+}
+
+// closeLog closes the log file opened for -log-file, if any
+func closeLog(log logger.Logger) {
+	if closer, ok := log.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to close log file: %v\n", err)
+		}
+	}
+}
+
+// shutdownGracePeriod is how long an interrupted run gets to stop on its own
+// It covers a navigation timeout plus writing the partial export
+const shutdownGracePeriod = 45 * time.Second
+
+// interruptContext returns a context cancelled on the first SIGINT or SIGTERM,
+// so the run can stop cleanly. A second signal, or a run still going after
+// shutdownGracePeriod, kills the launched browsers, which run in their own
+// process group and would otherwise outlive the process, and exits
+func interruptContext(log logger.Logger) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		sig := <-signals
+		log.Warn("Received %v, stopping (press Ctrl+C again to quit immediately)", sig)
+		cancel()
+
+		select {
+		case sig = <-signals:
+			log.Warn("Received %v again, closing browser processes", sig)
+		case <-time.After(shutdownGracePeriod):
+			log.Warn("Run did not stop within %v, closing browser processes", shutdownGracePeriod)
+		}
+		browser.KillLaunchedBrowsers()
+		os.Exit(130)
+	}()
+
+	return ctx
+}
+
+// checkOrphanBrowsers warns about browser processes leaked by earlier runs
+// and kills them when requested
+func checkOrphanBrowsers(log logger.Logger, kill bool) {
+	orphans := browser.FindOrphans()
+	if len(orphans) == 0 {
+		return
+	}
+
+	if !kill {
+		for _, orphan := range orphans {
+			log.Warn("Browser process %d left running by an earlier run (pid %d); use -kill-orphans to close it",
+				orphan.PID, orphan.OwnerPID)
+		}
+		return
+	}
+
+	errs := browser.KillOrphans(orphans)
+	for _, err := range errs {
+		log.Warn("%v", err)
+	}
+	log.Info("Closed %d browser processes left running by earlier runs", len(orphans)-len(errs))
 }
 
 // run contains the main application logic
-func run(log logger.Logger) error {
+func run(ctx context.Context, log logger.Logger, params *config.SearchParams) error {
 	// Create component-specific loggers
 	cliLog := log.WithPrefix("CLI")
 	configLog := log.WithPrefix("Config")
@@ -69,10 +225,12 @@ func run(log logger.Logger) error {
 
 	// Initialize CLI
 	cli := cli.NewCLI(cliLog)
+	if params.JSONSummary {
+		cli.SetOutput(os.Stderr)
+	}
+	cli.SetLocale(params.Locale)
 
-	// Parse command-line flags
-	configLog.Info("Parsing command-line flags")
-	params := config.SetupFlags(configLog)
+	configLog.Debug("Parsed parameters: %s", params)
 
 	// Ensure required parameters are provided
 	configLog.Debug("Ensuring required parameters")
@@ -82,7 +240,7 @@ func run(log logger.Logger) error {
 
 	// Validate parameters
 	configLog.Debug("Validating parameters")
-	validator := &config.DefaultValidator{}
+	validator := &config.DefaultValidator{Log: configLog, Columns: result.ColumnNames()}
 	if err := validator.ValidateSearchParams(params); err != nil {
 		return err
 	}
@@ -104,6 +262,15 @@ func run(log logger.Logger) error {
 	searchLog.Info("Search URL: %s", searchURL)
 	cli.PrintSearchURL(searchURL)
 
+	// Stop before launching anything when only the URL was asked for
+	if params.DryRun {
+		cli.PrintBrowserInfo(cli.T("dryRun.done"))
+		return nil
+	}
+
+	// Look for browsers leaked by crashed runs before launching a new one
+	checkOrphanBrowsers(browserLog, params.KillOrphans)
+
 	// Initialize browser
 	browserLog.Info("Initializing browser")
 	// Configure browser options based on parameters
@@ -111,22 +278,54 @@ func run(log logger.Logger) error {
 	
 	// Apply user-configured options
 	browserOptions = browserOptions.
+		WithHeadless(params.Headless).
 		WithStealthMode(params.StealthMode).
 		WithRandomUserAgent(params.RandomUserAgent).
-		WithSlowMotion(params.SlowMotion)
+		WithSlowMotion(params.SlowMotion).
+		WithPollInterval(params.PollInterval).
+		WithElementTimeout(params.ElementTimeout)
 	
 	// Set proxy if provided
 	if params.Proxy != "" {
 		browserOptions = browserOptions.WithProxy(params.Proxy)
 	}
 	
+	// Draw the random user agent from the user's own list
+	if params.UserAgentFile != "" {
+		browserOptions = browserOptions.WithUserAgentFile(params.UserAgentFile)
+	}
+	
+	// Use a specific browser executable if provided
+	if params.ChromePath != "" {
+		browserOptions = browserOptions.WithChromePath(params.ChromePath)
+	}
+	
+	// Keep cookies and local storage between runs
+	if params.ProfileDir != "" {
+		browserOptions = browserOptions.WithUserDataDir(params.ProfileDir)
+	}
+	
+	// Size the window and viewport; zero keeps the default
+	if params.ViewportWidth > 0 {
+		browserOptions = browserOptions.WithViewport(params.ViewportWidth, params.ViewportHeight)
+	}
+	
 	// Create the browser instance with configured options
 	browserLog.Info("Creating browser with anti-blocking measures")
 	if params.StealthMode {
 		browserLog.Info("Stealth mode enabled to avoid detection")
 	}
 	
-	browser := browser.NewBrowser(browserLog, &browserOptions)
+	newBrowser := func() browser.Browser {
+		return browser.NewBrowser(browserLog, &browserOptions)
+	}
+	// Detail browsers run alongside the main one, so they can't share its
+	// profile and start with fresh ones
+	detailOptions := browserOptions.WithUserDataDir("")
+	newDetailBrowser := func() browser.Browser {
+		return browser.NewBrowser(browserLog, &detailOptions)
+	}
+	browser := newBrowser()
 
 	// Ensure browser is closed even if errors occur
 	defer func() {
@@ -137,46 +336,147 @@ func run(log logger.Logger) error {
 	}()
 
 	// Log browser anti-blocking configuration
-	browserLog.Info("Browser configuration: stealth=%v, random-ua=%v, delay=%v, proxy=%s",
-		params.StealthMode, params.RandomUserAgent, params.SlowMotion,
+	browserLog.Info("Browser configuration: headless=%v, stealth=%v, random-ua=%v, slow=%v, proxy=%s",
+		params.Headless, params.StealthMode, params.RandomUserAgent, params.SlowMotion,
 		params.Proxy)
 	
+	// Only list the page URLs the search would visit
+	if params.URLListFile != "" {
+		resultLog.Info("Writing page URL list to %s", params.URLListFile)
+		processor := result.NewResultProcessor(browser, resultLog)
+		count, err := processor.DumpPageURLs(ctx, params, searchURL)
+		if err != nil {
+			return err
+		}
+
+		cli.PrintBrowserInfo(cli.T("urlList.written", count, params.URLListFile))
+		return nil
+	}
+
+	// Only report how big the search is
+	if params.CountOnly {
+		processor := result.NewResultProcessor(browser, resultLog)
+		total, pages, err := processor.CountResults(ctx, searchURL)
+		if err != nil {
+			return err
+		}
+
+		cli.PrintBrowserInfo(cli.T("count.result", total, pages))
+		return nil
+	}
+
+	// Check CAPES and the selectors before committing to a long run
+	if params.Preflight || params.PreflightOnly {
+		processor := result.NewResultProcessor(browser, resultLog)
+		report := processor.Preflight(ctx, searchURL)
+
+		cli.PrintBrowserInfo(cli.T("preflight.title"))
+		for _, check := range report.Checks {
+			cli.PrintCheck(check.Name, check.OK, check.Detail)
+		}
+		if !report.Passed() {
+			return errors.NewBrowserError("preflight failed, not starting the extraction", nil)
+		}
+		cli.PrintBrowserInfo(cli.T("preflight.passed"))
+
+		if params.PreflightOnly {
+			return nil
+		}
+	}
+
 	// Determine if we're doing a simple view or exporting results
 	if params.ExportResults && params.OutputFile != "" {
 		// We're exporting results - use the result processor
 		resultLog.Info("Starting result export to %s", params.OutputFile)
-		cli.PrintBrowserInfo(fmt.Sprintf("Iniciando exportação de resultados para: %s", params.OutputFile))
-		cli.PrintBrowserInfo("Este processo pode demorar alguns minutos dependendo do número de resultados...")
+		cli.PrintBrowserInfo(cli.T("export.starting", params.OutputFile))
+		cli.PrintBrowserInfo(cli.T("export.mayTakeMinutes"))
 
 		// Create result processor
 		processor := result.NewResultProcessor(browser, resultLog)
 		
-		// Set browser to headless mode for export (optional)
-		// This could be made configurable with a flag
-		//browser.WithHeadless(true)
+		// Detail pages are opened in parallel, each worker with its own browser
+		processor.SetDetailBrowserFactory(newDetailBrowser)
+		
+		// Show how far along the export is, unless asked to stay quiet
+		if !params.Quiet {
+			progress := cli.NewProgressBar()
+			processor.SetProgressFunc(progress.Update)
+			defer progress.Done()
+		}
 		
 		// Process and export results
-		err := processor.ProcessSearchResults(params, searchURL)
+		// Ctrl+C cancels ctx, which stops at the next page and saves the results so far
+		err := processor.ProcessSearchResults(ctx, params, searchURL)
 		if err != nil {
 			return err
 		}
 		
 		// Show success message
-		cli.PrintBrowserInfo(fmt.Sprintf("Exportação concluída com sucesso para: %s", params.OutputFile))
-		cli.PrintBrowserInfo("Você pode abrir o arquivo CSV em um editor de planilhas como Excel ou LibreOffice Calc.")
+		summary := processor.Summary()
+		if summary.Interrupted {
+			cli.PrintBrowserInfo(cli.T("export.interrupted", summary.ResultsWritten, params.OutputFile))
+			if !params.StreamToDisk {
+				cli.PrintBrowserInfo(cli.T("export.resumeHint"))
+			}
+		} else {
+			if summary.ResultsCollected == 0 {
+				cli.PrintBrowserInfo(cli.T("export.noResults"))
+			}
+			cli.PrintExportCompletion(summary.PagesProcessed, processor.ExportStats())
+		}
+		cli.PrintBrowserInfo(cli.T("export.openHint"))
+
+		// Emit the machine-readable summary last so it is the final stdout line
+		if params.JSONSummary {
+			if err := cli.PrintJSONSummary(processor.Summary()); err != nil {
+				return err
+			}
+		}
 
 		return nil
 	} else {
 		// Simple view mode - just open the browser to show results
-		cli.PrintBrowserInfo("Abrindo navegador com a URL de busca...")
-		if err := browser.Open(searchURL); err != nil {
+		cli.PrintBrowserInfo(cli.T("view.opening"))
+		if err := browser.Open(ctx, searchURL); err != nil {
 			return err
 		}
 
 		// Keep browser open for viewing results
-		cli.PrintBrowserInfo("Busca realizada com sucesso.")
-		cli.PrintBrowserInfo("Mantendo navegador aberto por 30 segundos para visualização dos resultados.")
+		cli.PrintBrowserInfo(cli.T("view.success"))
+		if params.ViewTime == 0 {
+			cli.PrintBrowserInfo(cli.T("view.keepOpenUntilClosed"))
+		} else {
+			cli.PrintBrowserInfo(cli.T("view.keepOpen", params.ViewTime))
+		}
+
+		return waitForViewer(ctx, browser, params.ViewTime, browserLog)
+	}
+}
 
-		return browser.Wait(30 * time.Second)
+// waitForViewer keeps the browser open for duration (0 = until its window is
+// closed), closing it early when ctx is cancelled by Ctrl+C
+func waitForViewer(ctx context.Context, b browser.Browser, duration time.Duration, log logger.Logger) error {
+	watching := make(chan struct{})
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		select {
+		case <-ctx.Done():
+			log.Info("Interrupted, closing browser")
+			if err := b.Close(); err != nil {
+				log.Warn("Failed to close browser: %v", err)
+			}
+		case <-watching:
+		}
+	}()
+
+	err := b.Wait(duration)
+	close(watching)
+	<-closed
+
+	// Closing the window with Ctrl+C is how the user ends the view
+	if ctx.Err() != nil {
+		return nil
 	}
+	return err
 }
\ No newline at end of file
@@ -3,8 +3,11 @@ package browser
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"math/rand"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/go-rod/rod"
@@ -15,38 +18,59 @@ import (
 )
 
 // Browser defines the interface for browser interactions
+// Page operations take a context: cancelling it aborts the operation in
+// flight, e.g. a navigation to a server that stopped responding, while the
+// browser itself stays open until Close
 type Browser interface {
 	// Basic browser operations
 	// Open launches a browser and navigates to the provided URL
 	// Returns an error if the browser fails to launch or navigate
-	Open(url string) error
+	Open(ctx context.Context, url string) error
 	
 	// Navigate navigates to a new URL using the existing browser instance
 	// This should be used for subsequent navigation after Open
-	Navigate(url string) error
+	Navigate(ctx context.Context, url string) error
+	
+	// Reload re-requests the current page and waits for it to load
+	Reload(ctx context.Context) error
+	
+	// PageURL returns the URL of the current page, after any redirects
+	PageURL(ctx context.Context) (string, error)
 	
 	// Wait keeps the browser open for the specified duration
-	// If duration is 0, the browser remains open until Close is called
+	// If duration is 0, the browser remains open until Close is called or
+	// the user closes its window
 	Wait(duration time.Duration) error
 	
 	// Close closes the browser instance and cleans up resources
-	// Returns an error if cleanup fails
+	// Cleanup failures are logged but not returned
 	Close() error
+	
+	// CloseStrict closes the browser like Close but returns the page and
+	// browser close failures joined into a single error
+	CloseStrict() error
 
 	// DOM interaction methods
-	GetElements(selector string) ([]*rod.Element, error)
-	GetElement(selector string) (*rod.Element, error)
-	ElementExists(selector string) (bool, error)
-	ClickElement(selector string) error
-	GetElementText(selector string) (string, error)
-	GetElementAttribute(selector, attr string) (string, error)
-	WaitForElement(selector string, timeout time.Duration) error
-	WaitForNavigation(timeout time.Duration) error
-	ExtractLinks(selector string) ([]LinkData, error)
+	GetElements(ctx context.Context, selector string) ([]*rod.Element, error)
+	GetElement(ctx context.Context, selector string) (*rod.Element, error)
+	ElementExists(ctx context.Context, selector string) (bool, error)
+	ClickElement(ctx context.Context, selector string) error
+	GetElementText(ctx context.Context, selector string) (string, error)
+	GetElementAttribute(ctx context.Context, selector, attr string) (string, error)
+	GetElementHTML(ctx context.Context, selector string) (string, error)
+	WaitForElement(ctx context.Context, selector string, timeout time.Duration) error
+	WaitForElementGone(ctx context.Context, selector string, timeout time.Duration) error
+	WaitForAnyElement(ctx context.Context, selectors []string, timeout time.Duration) (string, error)
+	WaitForNavigation(ctx context.Context, timeout time.Duration) error
+	ExtractLinks(ctx context.Context, selector string) ([]LinkData, error)
 	
 	// Scrolling operations
-	ScrollToBottom() error
-	ScrollForDuration(duration time.Duration) error
+	ScrollToBottom(ctx context.Context) error
+	ScrollForDuration(ctx context.Context, duration time.Duration) error
+	
+	// Screenshot saves a full-page PNG of the current page to path,
+	// creating the parent directory if needed
+	Screenshot(ctx context.Context, path string) error
 }
 
 // BrowserOptions contains configuration options for the browser
@@ -65,6 +89,31 @@ type BrowserOptions struct {
 	SlowMotion         time.Duration
 	StealthMode        bool
 	Proxy              string
+	
+	// PollInterval is how often polling-based waits re-check the page
+	PollInterval time.Duration
+	
+	// ElementTimeout bounds each element lookup (GetElement, GetElements,
+	// ElementExists) without changing the timeout of the page itself
+	ElementTimeout time.Duration
+	
+	// ChromePath is the Chrome/Chromium executable to launch; empty lets
+	// Rod find or download a browser on its own
+	ChromePath string
+	
+	// UserAgentFile is a file with one user agent per line to draw the random
+	// user agent from; empty uses the built-in list
+	UserAgentFile string
+	
+	// UserDataDir is a profile directory kept between runs, so cookies and
+	// local storage persist; empty starts every browser with a fresh profile.
+	// Only one browser at a time can use a profile
+	UserDataDir string
+	
+	// ViewportWidth and ViewportHeight size the browser window and the page
+	// viewport; CAPES lazy-loads elements based on the viewport height
+	ViewportWidth  int
+	ViewportHeight int
 }
 
 // DefaultBrowserOptions provides sensible defaults
@@ -76,9 +125,13 @@ var DefaultBrowserOptions = BrowserOptions{
 	SlowMotion:        200 * time.Millisecond,
 	StealthMode:       true,
 	Proxy:             "",
+	PollInterval:      200 * time.Millisecond,
+	ElementTimeout:    5 * time.Second,
+	ViewportWidth:     1920,
+	ViewportHeight:    1080,
 }
 
-// Common user agents for randomization
+// Common user agents for randomization, used when no UserAgentFile is set
 var commonUserAgents = []string{
 	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/96.0.4664.110 Safari/537.36",
 	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/96.0.4664.45 Safari/537.36",
@@ -92,17 +145,47 @@ var commonUserAgents = []string{
 // Random number generator
 var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
 
-// getRandomUserAgent returns a random user agent from the list
-func getRandomUserAgent() string {
-	return commonUserAgents[rng.Intn(len(commonUserAgents))]
+// getRandomUserAgent returns a random user agent from agents, or from the
+// built-in list when agents is empty
+func getRandomUserAgent(agents []string) string {
+	if len(agents) == 0 {
+		agents = commonUserAgents
+	}
+	return agents[rng.Intn(len(agents))]
+}
+
+// LoadUserAgents reads a user agent list with one entry per line, skipping
+// blank lines and "#" comments. A file without entries is an error
+func LoadUserAgents(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewConfigError(fmt.Sprintf("failed to read user agent file %s", path), err)
+	}
+	
+	var agents []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		agents = append(agents, line)
+	}
+	
+	if len(agents) == 0 {
+		return nil, errors.NewConfigError(fmt.Sprintf("user agent file %s has no user agents", path), nil)
+	}
+	return agents, nil
 }
 
 // RodBrowser implements Browser using the Rod library
 type RodBrowser struct {
 	browser *rod.Browser
 	page    *rod.Page
+	pid     int // Process ID of the launched browser, tracked for orphan cleanup
 	log     logger.Logger
 	options BrowserOptions
+	agents  []string // User agents loaded from options.UserAgentFile
+	unlockProfile func() // Releases options.UserDataDir, set while it is locked
 	ctx     context.Context
 	cancel  context.CancelFunc
 }
@@ -130,14 +213,43 @@ func NewBrowser(log logger.Logger, options *BrowserOptions) Browser {
 }
 
 // Open launches a browser and navigates to the specified URL
-func (b *RodBrowser) Open(url string) error {
+func (b *RodBrowser) Open(ctx context.Context, url string) error {
 	b.log.Info("Launching browser...")
 	
+	if err := ctx.Err(); err != nil {
+		return errors.NewBrowserError("browser launch cancelled", err)
+	}
+	
 	// Will set timeout after browser is initialized
 	
 	// Configure and launch the browser
 	l := launcher.New().Headless(b.options.Headless).Leakless(false)
 	b.log.Debug("Disabled leakless mode to avoid antivirus detection")
+	
+	// Keep cookies and local storage in a persistent profile
+	if b.options.UserDataDir != "" {
+		if b.unlockProfile == nil {
+			unlock, err := lockProfile(b.options.UserDataDir)
+			if err != nil {
+				return err
+			}
+			b.unlockProfile = unlock
+		}
+		l = l.UserDataDir(b.options.UserDataDir)
+		b.log.Debug("Using browser profile: %s", b.options.UserDataDir)
+	}
+	
+	// Use the configured executable instead of the auto-managed one
+	if b.options.ChromePath != "" {
+		l = l.Bin(b.options.ChromePath)
+		b.log.Debug("Using browser executable: %s", b.options.ChromePath)
+	}
+	
+	// Open the window at the viewport size, so a visible browser shows the
+	// whole page instead of scrollbars around it
+	if b.hasViewport() {
+		l = l.Set("window-size", fmt.Sprintf("%d,%d", b.options.ViewportWidth, b.options.ViewportHeight))
+	}
 
 	// Apply anti-blocking measures
 	if b.options.StealthMode {
@@ -145,7 +257,10 @@ func (b *RodBrowser) Open(url string) error {
 		
 		// Set a random user agent if enabled
 		if b.options.RandomizeUserAgent {
-			userAgent := getRandomUserAgent()
+			if err := b.loadUserAgents(); err != nil {
+				return err
+			}
+			userAgent := getRandomUserAgent(b.agents)
 			l = l.Set("user-agent", userAgent)
 			b.log.Debug("Using random user agent: %s", userAgent)
 		}
@@ -164,8 +279,13 @@ func (b *RodBrowser) Open(url string) error {
 	
 	launchURL, err := l.Launch()
 	if err != nil {
+		if isLaunchFailure(err) {
+			return errors.WithCode(errors.NewConfigError("browser executable can't be started, check -chrome-path", err), errors.CodeBrowserNotFound)
+		}
 		return errors.NewBrowserError("failed to launch browser", err)
 	}
+	b.pid = l.PID()
+	tracker.track(b.pid, l.Kill)
 	
 	// Connect to the browser
 	browser := rod.New().ControlURL(launchURL)
@@ -173,6 +293,14 @@ func (b *RodBrowser) Open(url string) error {
 	if err != nil {
 		return errors.NewBrowserError("failed to connect to browser", err)
 	}
+	
+	// Pause before every input action (clicks, typing, mouse moves) so the
+	// interaction looks human; navigation and its timeouts are unaffected.
+	// Set before the page is created, which keeps its own copy of the setting
+	if b.options.SlowMotion > 0 {
+		browser = browser.SlowMotion(b.options.SlowMotion)
+		b.log.Debug("Slow motion enabled: %v between input actions", b.options.SlowMotion)
+	}
 	// Set the browser with timeout
 	b.browser = browser.Timeout(b.options.Timeout)
 	
@@ -185,12 +313,48 @@ func (b *RodBrowser) Open(url string) error {
 	}
 	b.page = page
 	
+	// Rod emulates a small laptop screen by default; override it so lazy-loaded
+	// elements and the pagination controls render without extra scrolling
+	if b.hasViewport() {
+		err = proto.EmulationSetDeviceMetricsOverride{
+			Width:             b.options.ViewportWidth,
+			Height:            b.options.ViewportHeight,
+			DeviceScaleFactor: 1,
+		}.Call(page.Context(ctx))
+		if err != nil {
+			b.log.Warn("Failed to set viewport to %dx%d: %v", b.options.ViewportWidth, b.options.ViewportHeight, err)
+		} else {
+			b.log.Debug("Viewport set to %dx%d", b.options.ViewportWidth, b.options.ViewportHeight)
+		}
+	}
+	
 	// Navigate to the URL
-	return b.navigateToURL(url)
+	return b.navigateToURL(ctx, url)
+}
+
+// loadUserAgents reads options.UserAgentFile on first use, so reopening the
+// browser doesn't read the file again
+func (b *RodBrowser) loadUserAgents() error {
+	if b.options.UserAgentFile == "" || b.agents != nil {
+		return nil
+	}
+	
+	agents, err := LoadUserAgents(b.options.UserAgentFile)
+	if err != nil {
+		return err
+	}
+	b.agents = agents
+	b.log.Debug("Loaded %d user agents from %s", len(agents), b.options.UserAgentFile)
+	return nil
+}
+
+// hasViewport reports whether a window and viewport size is configured
+func (b *RodBrowser) hasViewport() bool {
+	return b.options.ViewportWidth > 0 && b.options.ViewportHeight > 0
 }
 
 // Navigate navigates to a new URL using the existing browser instance
-func (b *RodBrowser) Navigate(url string) error {
+func (b *RodBrowser) Navigate(ctx context.Context, url string) error {
 	if b.browser == nil || b.page == nil {
 		return errors.NewBrowserError("browser not initialized, call Open first", nil)
 	}
@@ -198,23 +362,27 @@ func (b *RodBrowser) Navigate(url string) error {
 	b.log.Info("Navigating to URL: %s", url)
 	
 	// Use the existing page to navigate to the new URL
-	return b.navigateToURL(url)
+	return b.navigateToURL(ctx, url)
 }
 
 // navigateToURL is a helper method that navigates to a URL and waits for page load
-func (b *RodBrowser) navigateToURL(url string) error {
+func (b *RodBrowser) navigateToURL(ctx context.Context, url string) error {
 	if b.page == nil {
 		return errors.NewBrowserError("page not initialized", nil)
 	}
+	page := b.page.Context(ctx)
 	
 	// Navigate to the URL
-	err := b.page.Navigate(url)
+	err := page.Navigate(url)
 	if err != nil {
+		if isNetworkUnreachable(err) {
+			return errors.WithCode(errors.NewNetworkError(unreachableMessage, err), errors.CodeUnreachable)
+		}
 		return errors.NewBrowserError("failed to navigate to URL", err)
 	}
 	
 	// Wait for page to load
-	err = b.page.WaitLoad()
+	err = page.WaitLoad()
 	if err != nil {
 		return errors.NewBrowserError("failed to wait for page load", err)
 	}
@@ -227,13 +395,39 @@ func (b *RodBrowser) navigateToURL(url string) error {
 		// Add random delay to simulate human behavior
 		delay := time.Duration(500+rng.Intn(1000)) * time.Millisecond
 		b.log.Debug("Adding random delay of %v after page load", delay)
-		time.Sleep(delay)
+		if err := sleepContext(ctx, delay); err != nil {
+			return errors.NewBrowserError("navigation cancelled", err)
+		}
 	}
 	
 	b.log.Info("Page loaded successfully")
 	return nil
 }
 
+// Reload re-requests the current page and waits for it to load
+func (b *RodBrowser) Reload(ctx context.Context) error {
+	if b.browser == nil || b.page == nil {
+		return errors.NewBrowserError("browser not initialized, call Open first", nil)
+	}
+	
+	b.log.Info("Reloading current page")
+	
+	page := b.page.Context(ctx).Timeout(b.options.Timeout)
+	if err := page.Reload(); err != nil {
+		if isNetworkUnreachable(err) {
+			return errors.WithCode(errors.NewNetworkError(unreachableMessage, err), errors.CodeUnreachable)
+		}
+		return errors.NewBrowserError("failed to reload page", err)
+	}
+	
+	if err := page.WaitLoad(); err != nil {
+		return errors.NewBrowserError("failed to wait for page load after reload", err)
+	}
+	
+	b.log.Info("Page reloaded successfully")
+	return nil
+}
+
 // Wait keeps the browser open for the specified duration
 func (b *RodBrowser) Wait(duration time.Duration) error {
 	if b.browser == nil {
@@ -241,7 +435,8 @@ func (b *RodBrowser) Wait(duration time.Duration) error {
 	}
 	
 	if duration == 0 {
-		duration = b.options.DefaultWaitTime
+		b.log.Info("Keeping browser open until it is closed")
+		return b.waitUntilClosed()
 	}
 	
 	b.log.Info("Keeping browser open for %v", duration)
@@ -262,8 +457,84 @@ func (b *RodBrowser) Wait(duration time.Duration) error {
 	}
 }
 
+// sleepContext pauses for d, returning early with ctx's error if ctx is
+// done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// closedPollInterval is how often waitUntilClosed checks for the window
+const closedPollInterval = time.Second
+
+// waitUntilClosed blocks until Close is called or the user closes the
+// browser window, which shows up as the browser having no pages left
+func (b *RodBrowser) waitUntilClosed() error {
+	// Close clears b.browser from another goroutine, so keep our own reference
+	rodBrowser := b.browser
+	ticker := time.NewTicker(closedPollInterval)
+	defer ticker.Stop()
+	
+	for {
+		select {
+		case <-b.ctx.Done():
+			b.log.Debug("Wait canceled")
+			return fmt.Errorf("wait canceled: %w", b.ctx.Err())
+		case <-ticker.C:
+			pages, err := rodBrowser.Pages()
+			if err != nil || len(pages) == 0 {
+				b.log.Debug("Browser window closed by the user")
+				return nil
+			}
+		}
+	}
+}
+
 // Close closes the browser and cleans up resources with timeout handling
 func (b *RodBrowser) Close() error {
+	if errs := b.closeAll(); len(errs) > 0 {
+		// Log the errors but still consider the operation successful
+		b.log.Info("Browser resources cleaned up with some errors")
+		return nil // Return nil to avoid cascading errors
+	}
+	
+	b.log.Info("Browser closed successfully")
+	return nil
+}
+
+// CloseStrict closes the browser and returns every cleanup failure
+// Failures here sometimes mean a Chromium process was left running
+func (b *RodBrowser) CloseStrict() error {
+	if err := stderrors.Join(b.closeAll()...); err != nil {
+		return err
+	}
+	
+	b.log.Info("Browser closed successfully")
+	return nil
+}
+
+// closeAll closes the page and the browser, returning the failures
+func (b *RodBrowser) closeAll() []error {
+	var closePage, closeBrowser func() error
+	if b.page != nil {
+		closePage = b.page.Close
+	}
+	if b.browser != nil {
+		closeBrowser = b.browser.Close
+	}
+	return b.closeWith(closePage, closeBrowser)
+}
+
+// closeWith runs the given close steps, nil for a resource that isn't open,
+// and releases everything the browser holds, returning the failures
+func (b *RodBrowser) closeWith(closePage, closeBrowser func() error) []error {
 	b.cancel() // Cancel any ongoing operations
 	
 	b.log.Info("Closing browser...")
@@ -290,9 +561,9 @@ func (b *RodBrowser) Close() error {
 	}
 	
 	// Close page if it exists
-	if b.page != nil {
+	if closePage != nil {
 		// Use short timeout for page closing
-		err := closeWithTimeout(b.page.Close, "closing page", 5*time.Second)
+		err := closeWithTimeout(closePage, "closing page", 5*time.Second)
 		if err != nil {
 			b.log.Warn("Error closing page: %v (continuing anyway)", err)
 			errs = append(errs, errors.NewBrowserError("failed to close page", err))
@@ -301,24 +572,27 @@ func (b *RodBrowser) Close() error {
 	}
 	
 	// Close browser if it exists
-	if b.browser != nil {
+	if closeBrowser != nil {
 		// Use short timeout for browser closing
-		err := closeWithTimeout(b.browser.Close, "closing browser", 5*time.Second)
+		err := closeWithTimeout(closeBrowser, "closing browser", 5*time.Second)
 		if err != nil {
 			b.log.Warn("Error closing browser: %v (continuing anyway)", err)
 			errs = append(errs, errors.NewBrowserError("failed to close browser", err))
+		} else {
+			// Keep tracking a browser that failed to close, it may still be running
+			tracker.untrack(b.pid)
 		}
 		b.browser = nil
+		b.pid = 0
 	}
 	
-	if len(errs) > 0 {
-		// Log the errors but still consider the operation successful
-		b.log.Info("Browser resources cleaned up with some errors")
-		return nil // Return nil to avoid cascading errors
+	// The profile is free for the next browser once this one has exited
+	if b.unlockProfile != nil {
+		b.unlockProfile()
+		b.unlockProfile = nil
 	}
 	
-	b.log.Info("Browser closed successfully")
-	return nil
+	return errs
 }
 
 // WithHeadless creates a copy of options with headless setting modified
@@ -363,6 +637,43 @@ func (o BrowserOptions) WithRandomUserAgent(enabled bool) BrowserOptions {
 	return o
 }
 
+// WithElementTimeout creates a copy of options with the element lookup timeout modified
+func (o BrowserOptions) WithElementTimeout(timeout time.Duration) BrowserOptions {
+	o.ElementTimeout = timeout
+	return o
+}
+
+// WithChromePath creates a copy of options with the browser executable path
+func (o BrowserOptions) WithChromePath(path string) BrowserOptions {
+	o.ChromePath = path
+	return o
+}
+
+// WithUserAgentFile creates a copy of options with the user agent list file
+func (o BrowserOptions) WithUserAgentFile(path string) BrowserOptions {
+	o.UserAgentFile = path
+	return o
+}
+
+// WithUserDataDir creates a copy of options using a persistent profile directory
+func (o BrowserOptions) WithUserDataDir(dir string) BrowserOptions {
+	o.UserDataDir = dir
+	return o
+}
+
+// WithViewport creates a copy of options with the window and viewport size
+func (o BrowserOptions) WithViewport(width, height int) BrowserOptions {
+	o.ViewportWidth = width
+	o.ViewportHeight = height
+	return o
+}
+
+// WithPollInterval creates a copy of options with the polling interval modified
+func (o BrowserOptions) WithPollInterval(interval time.Duration) BrowserOptions {
+	o.PollInterval = interval
+	return o
+}
+
 // executeStealthScripts applies JavaScript to hide automation markers
 func (b *RodBrowser) executeStealthScripts(page *rod.Page) {
 	b.log.Debug("Stealth scripts disabled due to compatibility issues")
@@ -0,0 +1,45 @@
+package browser
+
+import (
+	stderrors "errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/alexandreffaria/reviu/internal/logger"
+)
+
+// newTestBrowser returns a browser that hasn't been opened, logging nowhere
+func newTestBrowser() *RodBrowser {
+	return NewBrowser(logger.NewLogger(logger.WithWriter(io.Discard)), nil).(*RodBrowser)
+}
+
+func TestCloseStrictJoinsFailures(t *testing.T) {
+	pageErr := stderrors.New("page target crashed")
+	browserErr := stderrors.New("browser process not responding")
+
+	// The same failures CloseStrict joins from a real page and browser
+	b := newTestBrowser()
+	err := stderrors.Join(b.closeWith(
+		func() error { return pageErr },
+		func() error { return browserErr },
+	)...)
+
+	if !stderrors.Is(err, pageErr) || !stderrors.Is(err, browserErr) {
+		t.Fatalf("joined close error = %v, want both the page and browser failures", err)
+	}
+	for _, want := range []string{"failed to close page", "failed to close browser"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("joined close error = %q, want it to mention %q", err, want)
+		}
+	}
+}
+
+func TestCloseWithoutOpen(t *testing.T) {
+	if err := newTestBrowser().CloseStrict(); err != nil {
+		t.Errorf("CloseStrict() = %v for a browser never opened, want nil", err)
+	}
+	if err := newTestBrowser().Close(); err != nil {
+		t.Errorf("Close() = %v for a browser never opened, want nil", err)
+	}
+}
@@ -0,0 +1,412 @@
+// Package browsertest provides a scriptable Browser for exercising code that
+// drives the browser without launching Chromium
+package browsertest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod"
+
+	"github.com/alexandreffaria/reviu/internal/browser"
+	"github.com/alexandreffaria/reviu/internal/errors"
+)
+
+// Page holds the scripted content of one URL
+type Page struct {
+	Links      map[string][]browser.LinkData // Links returned by ExtractLinks, by selector
+	Texts      map[string]string             // Element texts, by selector
+	HTML       map[string]string             // Element inner HTML, by selector
+	Attributes map[string]map[string]string  // Element attributes, by selector and name
+}
+
+// has reports whether the page has content for selector
+func (p *Page) has(selector string) bool {
+	if p == nil {
+		return false
+	}
+	if _, ok := p.Links[selector]; ok {
+		return true
+	}
+	if _, ok := p.Texts[selector]; ok {
+		return true
+	}
+	if _, ok := p.HTML[selector]; ok {
+		return true
+	}
+	_, ok := p.Attributes[selector]
+	return ok
+}
+
+// Call records one method call made on the fake
+type Call struct {
+	Method string
+	Args   []string
+}
+
+// FakeBrowser implements browser.Browser over scripted pages
+// It answers from the page of the last URL opened or navigated to; selectors
+// without scripted content behave as missing elements. It never sleeps, so
+// waits succeed or fail immediately
+type FakeBrowser struct {
+	mu       sync.Mutex
+	pages    map[string]*Page
+	failures map[string]error
+	current  string
+	calls    []Call
+}
+
+// Ensure FakeBrowser satisfies the interface it stands in for
+var _ browser.Browser = (*FakeBrowser)(nil)
+
+// NewFakeBrowser creates a fake with no scripted pages
+func NewFakeBrowser() *FakeBrowser {
+	return &FakeBrowser{
+		pages:    make(map[string]*Page),
+		failures: make(map[string]error),
+	}
+}
+
+// SetPage scripts the content served for url
+func (f *FakeBrowser) SetPage(url string, page *Page) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pages[url] = page
+}
+
+// SetLinks scripts the links matching selector on url
+func (f *FakeBrowser) SetLinks(url, selector string, links []browser.LinkData) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	page := f.page(url)
+	if page.Links == nil {
+		page.Links = make(map[string][]browser.LinkData)
+	}
+	page.Links[selector] = links
+}
+
+// SetText scripts the text of the element matching selector on url
+func (f *FakeBrowser) SetText(url, selector, text string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	page := f.page(url)
+	if page.Texts == nil {
+		page.Texts = make(map[string]string)
+	}
+	page.Texts[selector] = text
+}
+
+// FailOn makes every call to method fail with err; a nil err clears it
+// Method names match the Browser interface, e.g. "Open" or "GetElementText"
+func (f *FakeBrowser) FailOn(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err == nil {
+		delete(f.failures, method)
+		return
+	}
+	f.failures[method] = err
+}
+
+// Calls returns a copy of the calls made so far, in order
+func (f *FakeBrowser) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// CallCount returns how many times method was called
+func (f *FakeBrowser) CallCount(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for _, call := range f.calls {
+		if call.Method == method {
+			count++
+		}
+	}
+	return count
+}
+
+// CurrentURL returns the URL of the page the fake is on
+func (f *FakeBrowser) CurrentURL() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.current
+}
+
+// page returns the scripted page for url, creating it; callers hold mu
+func (f *FakeBrowser) page(url string) *Page {
+	page, ok := f.pages[url]
+	if !ok {
+		page = &Page{}
+		f.pages[url] = page
+	}
+	return page
+}
+
+// record logs a call and returns the scripted failure for method, if any
+// The caller must hold mu
+func (f *FakeBrowser) record(method string, args ...string) error {
+	f.calls = append(f.calls, Call{Method: method, Args: args})
+	return f.failures[method]
+}
+
+// recordCtx is record for page operations, which fail with ctx's error once
+// ctx is done, like the real browser. The caller must hold mu
+func (f *FakeBrowser) recordCtx(ctx context.Context, method string, args ...string) error {
+	if err := f.record(method, args...); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return errors.NewBrowserError(fmt.Sprintf("%s cancelled", method), err)
+	}
+	return nil
+}
+
+// currentPage returns the scripted page being shown; callers hold mu
+func (f *FakeBrowser) currentPage() *Page {
+	return f.pages[f.current]
+}
+
+// notFound builds the error returned for selectors without scripted content
+func notFound(selector string) error {
+	return errors.NewBrowserError(fmt.Sprintf("element not found: %s", selector), nil)
+}
+
+// Open implements browser.Browser
+func (f *FakeBrowser) Open(ctx context.Context, url string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.recordCtx(ctx, "Open", url); err != nil {
+		return err
+	}
+	f.current = url
+	return nil
+}
+
+// Navigate implements browser.Browser
+func (f *FakeBrowser) Navigate(ctx context.Context, url string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.recordCtx(ctx, "Navigate", url); err != nil {
+		return err
+	}
+	f.current = url
+	return nil
+}
+
+// Reload implements browser.Browser
+func (f *FakeBrowser) Reload(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.recordCtx(ctx, "Reload")
+}
+
+// PageURL implements browser.Browser, returning the last URL opened or navigated to
+func (f *FakeBrowser) PageURL(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.recordCtx(ctx, "PageURL"); err != nil {
+		return "", err
+	}
+	return f.current, nil
+}
+
+// Wait implements browser.Browser without sleeping
+func (f *FakeBrowser) Wait(duration time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.record("Wait", duration.String())
+}
+
+// Close implements browser.Browser
+func (f *FakeBrowser) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.record("Close")
+}
+
+// CloseStrict implements browser.Browser
+func (f *FakeBrowser) CloseStrict() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.record("CloseStrict")
+}
+
+// GetElements implements browser.Browser
+// rod elements can't be built without a real page, so selectors without
+// scripted content match nothing and scripted ones return an error
+func (f *FakeBrowser) GetElements(ctx context.Context, selector string) ([]*rod.Element, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.recordCtx(ctx, "GetElements", selector); err != nil {
+		return nil, err
+	}
+	if !f.currentPage().has(selector) {
+		return []*rod.Element{}, nil
+	}
+	return nil, errors.NewBrowserError("GetElements can't return scripted elements from FakeBrowser", nil)
+}
+
+// GetElement implements browser.Browser, with the same limits as GetElements
+func (f *FakeBrowser) GetElement(ctx context.Context, selector string) (*rod.Element, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.recordCtx(ctx, "GetElement", selector); err != nil {
+		return nil, err
+	}
+	return nil, errors.NewBrowserError("GetElement is not supported by FakeBrowser", nil)
+}
+
+// ElementExists implements browser.Browser
+func (f *FakeBrowser) ElementExists(ctx context.Context, selector string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.recordCtx(ctx, "ElementExists", selector); err != nil {
+		return false, err
+	}
+	return f.currentPage().has(selector), nil
+}
+
+// ClickElement implements browser.Browser
+func (f *FakeBrowser) ClickElement(ctx context.Context, selector string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.recordCtx(ctx, "ClickElement", selector); err != nil {
+		return err
+	}
+	if !f.currentPage().has(selector) {
+		return notFound(selector)
+	}
+	return nil
+}
+
+// GetElementText implements browser.Browser
+func (f *FakeBrowser) GetElementText(ctx context.Context, selector string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.recordCtx(ctx, "GetElementText", selector); err != nil {
+		return "", err
+	}
+	if page := f.currentPage(); page != nil {
+		if text, ok := page.Texts[selector]; ok {
+			return text, nil
+		}
+	}
+	return "", notFound(selector)
+}
+
+// GetElementAttribute implements browser.Browser
+func (f *FakeBrowser) GetElementAttribute(ctx context.Context, selector, attr string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.recordCtx(ctx, "GetElementAttribute", selector, attr); err != nil {
+		return "", err
+	}
+	if page := f.currentPage(); page != nil {
+		if value, ok := page.Attributes[selector][attr]; ok {
+			return value, nil
+		}
+	}
+	return "", notFound(selector)
+}
+
+// GetElementHTML implements browser.Browser
+func (f *FakeBrowser) GetElementHTML(ctx context.Context, selector string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.recordCtx(ctx, "GetElementHTML", selector); err != nil {
+		return "", err
+	}
+	if page := f.currentPage(); page != nil {
+		if html, ok := page.HTML[selector]; ok {
+			return html, nil
+		}
+	}
+	return "", notFound(selector)
+}
+
+// WaitForElement implements browser.Browser, failing at once if the element is missing
+func (f *FakeBrowser) WaitForElement(ctx context.Context, selector string, timeout time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.recordCtx(ctx, "WaitForElement", selector); err != nil {
+		return err
+	}
+	if !f.currentPage().has(selector) {
+		return errors.NewBrowserError(fmt.Sprintf("timeout waiting for element: %s", selector), nil)
+	}
+	return nil
+}
+
+// WaitForElementGone implements browser.Browser, failing at once if the element is present
+func (f *FakeBrowser) WaitForElementGone(ctx context.Context, selector string, timeout time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.recordCtx(ctx, "WaitForElementGone", selector); err != nil {
+		return err
+	}
+	if f.currentPage().has(selector) {
+		return errors.NewBrowserError(fmt.Sprintf("timeout waiting for element to disappear: %s", selector), nil)
+	}
+	return nil
+}
+
+// WaitForAnyElement implements browser.Browser, returning the first selector present
+func (f *FakeBrowser) WaitForAnyElement(ctx context.Context, selectors []string, timeout time.Duration) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.recordCtx(ctx, "WaitForAnyElement", selectors...); err != nil {
+		return "", err
+	}
+	for _, selector := range selectors {
+		if f.currentPage().has(selector) {
+			return selector, nil
+		}
+	}
+	return "", errors.NewBrowserError("timeout waiting for any element", nil)
+}
+
+// WaitForNavigation implements browser.Browser
+func (f *FakeBrowser) WaitForNavigation(ctx context.Context, timeout time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.recordCtx(ctx, "WaitForNavigation")
+}
+
+// ExtractLinks implements browser.Browser; missing selectors yield no links
+func (f *FakeBrowser) ExtractLinks(ctx context.Context, selector string) ([]browser.LinkData, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.recordCtx(ctx, "ExtractLinks", selector); err != nil {
+		return nil, err
+	}
+	if page := f.currentPage(); page != nil {
+		return append([]browser.LinkData(nil), page.Links[selector]...), nil
+	}
+	return []browser.LinkData{}, nil
+}
+
+// ScrollToBottom implements browser.Browser
+func (f *FakeBrowser) ScrollToBottom(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.recordCtx(ctx, "ScrollToBottom")
+}
+
+// ScrollForDuration implements browser.Browser without sleeping
+func (f *FakeBrowser) ScrollForDuration(ctx context.Context, duration time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.recordCtx(ctx, "ScrollForDuration", duration.String())
+}
+
+// Screenshot implements browser.Browser, recording the path without writing a file
+func (f *FakeBrowser) Screenshot(ctx context.Context, path string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.recordCtx(ctx, "Screenshot", path)
+}
@@ -0,0 +1,149 @@
+package browsertest
+
+import (
+	"context"
+	stderrors "errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alexandreffaria/reviu/internal/browser"
+	"github.com/alexandreffaria/reviu/internal/errors"
+)
+
+func TestFakeBrowserRecordsCalls(t *testing.T) {
+	fake := NewFakeBrowser()
+	ctx := context.Background()
+
+	fake.Open(ctx, "https://example.org/a")
+	fake.GetElementText(ctx, "#title")
+	fake.Wait(time.Second)
+	fake.Navigate(ctx, "https://example.org/b")
+	fake.GetElementAttribute(ctx, "a", "href")
+
+	want := []Call{
+		{Method: "Open", Args: []string{"https://example.org/a"}},
+		{Method: "GetElementText", Args: []string{"#title"}},
+		{Method: "Wait", Args: []string{"1s"}},
+		{Method: "Navigate", Args: []string{"https://example.org/b"}},
+		{Method: "GetElementAttribute", Args: []string{"a", "href"}},
+	}
+	if got := fake.Calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Calls() = %v, want %v", got, want)
+	}
+	if got := fake.CallCount("Open"); got != 1 {
+		t.Errorf("CallCount(Open) = %d, want 1", got)
+	}
+	if got := fake.CurrentURL(); got != "https://example.org/b" {
+		t.Errorf("CurrentURL() = %q, want the last navigated URL", got)
+	}
+}
+
+func TestFakeBrowserServesScriptedPage(t *testing.T) {
+	fake := NewFakeBrowser()
+	ctx := context.Background()
+	links := []browser.LinkData{{Text: "First", URL: "/1"}, {Text: "Second", URL: "/2"}}
+
+	fake.SetLinks("https://example.org/a", "a.result", links)
+	fake.SetText("https://example.org/a", "#count", "2 resultados")
+	fake.SetPage("https://example.org/b", &Page{
+		HTML:       map[string]string{"#abstract": "<p>Text</p>"},
+		Attributes: map[string]map[string]string{"a.doi": {"href": "https://doi.org/10.1/x"}},
+	})
+
+	fake.Open(ctx, "https://example.org/a")
+	if got, err := fake.ExtractLinks(ctx, "a.result"); err != nil || !reflect.DeepEqual(got, links) {
+		t.Errorf("ExtractLinks() = %v, %v; want %v", got, err, links)
+	}
+	if got, err := fake.GetElementText(ctx, "#count"); err != nil || got != "2 resultados" {
+		t.Errorf("GetElementText() = %q, %v; want the scripted text", got, err)
+	}
+	if exists, _ := fake.ElementExists(ctx, "#count"); !exists {
+		t.Error("ElementExists() = false for a scripted selector")
+	}
+	if err := fake.WaitForElement(ctx, "#missing", time.Second); err == nil {
+		t.Error("WaitForElement() succeeded for a selector without content")
+	}
+
+	// Content belongs to its URL, so it changes with the page
+	fake.Navigate(ctx, "https://example.org/b")
+	if got, err := fake.GetElementHTML(ctx, "#abstract"); err != nil || got != "<p>Text</p>" {
+		t.Errorf("GetElementHTML() = %q, %v; want the scripted HTML", got, err)
+	}
+	if got, err := fake.GetElementAttribute(ctx, "a.doi", "href"); err != nil || got != "https://doi.org/10.1/x" {
+		t.Errorf("GetElementAttribute() = %q, %v; want the scripted value", got, err)
+	}
+	if _, err := fake.GetElementText(ctx, "#count"); err == nil {
+		t.Error("GetElementText() found text scripted for another page")
+	}
+	if got, _ := fake.ExtractLinks(ctx, "a.result"); len(got) != 0 {
+		t.Errorf("ExtractLinks() = %v, want no links on another page", got)
+	}
+	if got, err := fake.WaitForAnyElement(ctx, []string{"#missing", "a.doi"}, time.Second); err != nil || got != "a.doi" {
+		t.Errorf("WaitForAnyElement() = %q, %v; want the first present selector", got, err)
+	}
+}
+
+func TestFakeBrowserFailOn(t *testing.T) {
+	fake := NewFakeBrowser()
+	ctx := context.Background()
+	failure := errors.NewNetworkError("connection refused", nil)
+
+	fake.FailOn("Open", failure)
+	if err := fake.Open(ctx, "https://example.org/a"); err != failure {
+		t.Fatalf("Open() = %v, want the scripted failure", err)
+	}
+	if got := fake.CurrentURL(); got != "" {
+		t.Errorf("CurrentURL() = %q after a failed Open, want it unchanged", got)
+	}
+	if got := fake.CallCount("Open"); got != 1 {
+		t.Errorf("CallCount(Open) = %d, want failed calls recorded too", got)
+	}
+
+	fake.FailOn("Open", nil)
+	if err := fake.Open(ctx, "https://example.org/a"); err != nil {
+		t.Errorf("Open() = %v after clearing the failure, want nil", err)
+	}
+}
+
+func TestFakeBrowserCancelledContext(t *testing.T) {
+	fake := NewFakeBrowser()
+	fake.SetText("https://example.org/a", "#count", "2 resultados")
+	fake.Open(context.Background(), "https://example.org/a")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := fake.GetElementText(ctx, "#count")
+	if !stderrors.Is(err, context.Canceled) {
+		t.Errorf("GetElementText() = %v, want an error wrapping context.Canceled", err)
+	}
+	if !errors.IsErrorType(err, errors.Browser) {
+		t.Errorf("GetElementText() = %v, want a browser error", err)
+	}
+	// Closing doesn't take a context and still works
+	if err := fake.Close(); err != nil {
+		t.Errorf("Close() = %v, want nil", err)
+	}
+}
+
+func TestGetElementHTMLPassthrough(t *testing.T) {
+	const page = "https://example.org/details"
+	authors := `<a href="/autor/1">Silva, J.</a>; <a href="/autor/2">Souza &amp; Filho, M.</a>`
+
+	fake := NewFakeBrowser()
+	fake.SetPage(page, &Page{HTML: map[string]string{"#authors": authors}})
+
+	// Callers only see the Browser interface
+	var b browser.Browser = fake
+	ctx := context.Background()
+	b.Open(ctx, page)
+
+	got, err := b.GetElementHTML(ctx, "#authors")
+	if err != nil || got != authors {
+		t.Errorf("GetElementHTML() = %q, %v; want the markup unchanged", got, err)
+	}
+	if _, err := b.GetElementHTML(ctx, "#citation"); !errors.IsErrorType(err, errors.Browser) {
+		t.Errorf("GetElementHTML() of a missing element = %v, want a browser error", err)
+	}
+}
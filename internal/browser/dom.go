@@ -1,7 +1,10 @@
 package browser
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -18,7 +21,7 @@ type LinkData struct {
 }
 
 // ScrollToBottom scrolls the page to the bottom
-func (b *RodBrowser) ScrollToBottom() error {
+func (b *RodBrowser) ScrollToBottom(ctx context.Context) error {
 	if b.page == nil {
 		return errors.NewBrowserError("browser page not initialized, call Open first", nil)
 	}
@@ -26,7 +29,7 @@ func (b *RodBrowser) ScrollToBottom() error {
 	b.log.Debug("Scrolling to bottom of page...")
 	
 	// Execute JavaScript to scroll to the bottom
-	_, err := b.page.Eval(`window.scrollTo(0, document.body.scrollHeight)`)
+	_, err := b.page.Context(ctx).Eval(`window.scrollTo(0, document.body.scrollHeight)`)
 	if err != nil {
 		return errors.NewBrowserError("failed to scroll to bottom", err)
 	}
@@ -35,7 +38,7 @@ func (b *RodBrowser) ScrollToBottom() error {
 }
 
 // ScrollForDuration scrolls the page repeatedly for a specified duration
-func (b *RodBrowser) ScrollForDuration(duration time.Duration) error {
+func (b *RodBrowser) ScrollForDuration(ctx context.Context, duration time.Duration) error {
 	if b.page == nil {
 		return errors.NewBrowserError("browser page not initialized, call Open first", nil)
 	}
@@ -46,15 +49,18 @@ func (b *RodBrowser) ScrollForDuration(duration time.Duration) error {
 	startTime := time.Now()
 	
 	// Scroll multiple times until the duration is reached
+	page := b.page.Context(ctx)
 	for time.Since(startTime) < duration {
 		// Scroll down
-		_, err := b.page.Eval(`window.scrollBy(0, 500)`)
+		_, err := page.Eval(`window.scrollBy(0, 500)`)
 		if err != nil {
 			return errors.NewBrowserError("failed to scroll page", err)
 		}
 		
 		// Brief pause between scrolls
-		time.Sleep(200 * time.Millisecond)
+		if err := sleepContext(ctx, 200*time.Millisecond); err != nil {
+			return errors.NewBrowserError("scrolling cancelled", err)
+		}
 	}
 	
 	b.log.Debug("Completed scrolling for %v", duration)
@@ -62,35 +68,40 @@ func (b *RodBrowser) ScrollForDuration(duration time.Duration) error {
 }
 
 // GetElements returns all elements matching the provided CSS selector
-func (b *RodBrowser) GetElements(selector string) ([]*rod.Element, error) {
+func (b *RodBrowser) GetElements(ctx context.Context, selector string) ([]*rod.Element, error) {
 	if b.page == nil {
 		return nil, errors.NewBrowserError("browser page not initialized, call Open first", nil)
 	}
 	
-	// Set a timeout for this operation
-	b.page = b.page.Timeout(5 * time.Second)
+	// Bound this lookup only; the page keeps its own timeout
+	page := b.page.Context(ctx).Timeout(b.elementTimeout())
+	defer page.CancelTimeout()
 	
 	// Attempt to find the elements
-	elements, err := b.page.Elements(selector)
+	elements, err := page.Elements(selector)
 	if err != nil {
 		return nil, errors.NewBrowserError(fmt.Sprintf("failed to find elements with selector: %s", selector), err)
 	}
+	for i, element := range elements {
+		elements[i] = b.detach(ctx, element)
+	}
 	
 	b.log.Debug("Found %d elements matching selector: %s", len(elements), selector)
 	return elements, nil
 }
 
 // GetElement returns the first element matching the provided CSS selector
-func (b *RodBrowser) GetElement(selector string) (*rod.Element, error) {
+func (b *RodBrowser) GetElement(ctx context.Context, selector string) (*rod.Element, error) {
 	if b.page == nil {
 		return nil, errors.NewBrowserError("browser page not initialized, call Open first", nil)
 	}
 	
-	// Set a timeout for this operation
-	b.page = b.page.Timeout(5 * time.Second)
+	// Bound this lookup only; the page keeps its own timeout
+	page := b.page.Context(ctx).Timeout(b.elementTimeout())
+	defer page.CancelTimeout()
 	
 	// Attempt to find the element
-	element, err := b.page.Element(selector)
+	element, err := page.Element(selector)
 	if err != nil {
 		return nil, errors.NewBrowserError(fmt.Sprintf("failed to find element with selector: %s", selector), err)
 	}
@@ -99,37 +110,49 @@ func (b *RodBrowser) GetElement(selector string) (*rod.Element, error) {
 		return nil, errors.NewBrowserError(fmt.Sprintf("element not found with selector: %s", selector), nil)
 	}
 	
-	return element, nil
+	return b.detach(ctx, element), nil
+}
+
+// elementTimeout returns how long a single element lookup may take
+func (b *RodBrowser) elementTimeout() time.Duration {
+	if b.options.ElementTimeout > 0 {
+		return b.options.ElementTimeout
+	}
+	return DefaultBrowserOptions.ElementTimeout
+}
+
+// detach rebinds an element found under a lookup timeout to the caller's
+// context, so it stays usable after the lookup returns
+func (b *RodBrowser) detach(ctx context.Context, element *rod.Element) *rod.Element {
+	return element.Context(ctx)
 }
 
 // ElementExists checks if an element exists in the page
-func (b *RodBrowser) ElementExists(selector string) (bool, error) {
+func (b *RodBrowser) ElementExists(ctx context.Context, selector string) (bool, error) {
 	if b.page == nil {
 		return false, errors.NewBrowserError("browser page not initialized, call Open first", nil)
 	}
 	
-	// Attempt to find the element
-	element, err := b.page.Element(selector)
+	// Check without waiting for the element to appear
+	page := b.page.Context(ctx).Timeout(b.elementTimeout())
+	defer page.CancelTimeout()
 	
-	// Element not found
+	exists, _, err := page.Has(selector)
 	if err != nil {
-		if strings.Contains(err.Error(), "element not found") {
-			return false, nil
-		}
 		return false, errors.NewBrowserError(fmt.Sprintf("error checking if element exists: %s", selector), err)
 	}
 	
-	return element != nil, nil
+	return exists, nil
 }
 
 // ClickElement clicks on an element matching the provided selector
-func (b *RodBrowser) ClickElement(selector string) error {
+func (b *RodBrowser) ClickElement(ctx context.Context, selector string) error {
 	if b.page == nil {
 		return errors.NewBrowserError("browser page not initialized, call Open first", nil)
 	}
 	
 	// Get the element
-	element, err := b.GetElement(selector)
+	element, err := b.GetElement(ctx, selector)
 	if err != nil {
 		return err
 	}
@@ -151,13 +174,13 @@ func (b *RodBrowser) ClickElement(selector string) error {
 }
 
 // GetElementText returns the text content of an element
-func (b *RodBrowser) GetElementText(selector string) (string, error) {
+func (b *RodBrowser) GetElementText(ctx context.Context, selector string) (string, error) {
 	if b.page == nil {
 		return "", errors.NewBrowserError("browser page not initialized, call Open first", nil)
 	}
 	
 	// Get the element
-	element, err := b.GetElement(selector)
+	element, err := b.GetElement(ctx, selector)
 	if err != nil {
 		return "", err
 	}
@@ -172,13 +195,13 @@ func (b *RodBrowser) GetElementText(selector string) (string, error) {
 }
 
 // GetElementAttribute returns the value of an attribute on an element
-func (b *RodBrowser) GetElementAttribute(selector, attr string) (string, error) {
+func (b *RodBrowser) GetElementAttribute(ctx context.Context, selector, attr string) (string, error) {
 	if b.page == nil {
 		return "", errors.NewBrowserError("browser page not initialized, call Open first", nil)
 	}
 	
 	// Get the element
-	element, err := b.GetElement(selector)
+	element, err := b.GetElement(ctx, selector)
 	if err != nil {
 		return "", err
 	}
@@ -196,8 +219,31 @@ func (b *RodBrowser) GetElementAttribute(selector, attr string) (string, error)
 	return *value, nil
 }
 
+// GetElementHTML returns the inner HTML of an element
+// Unlike GetElementText, this keeps the markup so structured content
+// (e.g. separate author links) can be parsed by the caller
+func (b *RodBrowser) GetElementHTML(ctx context.Context, selector string) (string, error) {
+	if b.page == nil {
+		return "", errors.NewBrowserError("browser page not initialized, call Open first", nil)
+	}
+	
+	// Get the element
+	element, err := b.GetElement(ctx, selector)
+	if err != nil {
+		return "", err
+	}
+	
+	// Read the innerHTML property; element.HTML() would include the element itself
+	html, err := element.Property("innerHTML")
+	if err != nil {
+		return "", errors.NewBrowserError(fmt.Sprintf("failed to get HTML from element: %s", selector), err)
+	}
+	
+	return html.Str(), nil
+}
+
 // WaitForElement waits for an element to appear in the page
-func (b *RodBrowser) WaitForElement(selector string, timeout time.Duration) error {
+func (b *RodBrowser) WaitForElement(ctx context.Context, selector string, timeout time.Duration) error {
 	if b.page == nil {
 		return errors.NewBrowserError("browser page not initialized, call Open first", nil)
 	}
@@ -206,11 +252,12 @@ func (b *RodBrowser) WaitForElement(selector string, timeout time.Duration) erro
 		timeout = 10 * time.Second // Default timeout
 	}
 	
-	// Set timeout for this operation
-	b.page = b.page.Timeout(timeout)
+	// Bound this wait only; the page keeps its own timeout
+	page := b.page.Context(ctx).Timeout(timeout)
+	defer page.CancelTimeout()
 	
 	// Wait for the element to appear
-	err := b.page.Timeout(timeout).WaitElementsMoreThan(selector, 0)
+	err := page.WaitElementsMoreThan(selector, 0)
 	if err != nil {
 		return errors.NewBrowserError(fmt.Sprintf("timeout waiting for element: %s", selector), err)
 	}
@@ -219,8 +266,85 @@ func (b *RodBrowser) WaitForElement(selector string, timeout time.Duration) erro
 	return nil
 }
 
+// WaitForElementGone waits until no element matches the selector, e.g. for a
+// loading overlay to disappear
+func (b *RodBrowser) WaitForElementGone(ctx context.Context, selector string, timeout time.Duration) error {
+	if b.page == nil {
+		return errors.NewBrowserError("browser page not initialized, call Open first", nil)
+	}
+	
+	err := pollUntil(ctx, timeout, b.options.PollInterval, func() (bool, error) {
+		exists, err := b.ElementExists(ctx, selector)
+		return !exists, err
+	})
+	if err != nil {
+		return errors.NewBrowserError(fmt.Sprintf("timeout waiting for element to disappear: %s", selector), err)
+	}
+	
+	b.log.Debug("Element gone: %s", selector)
+	return nil
+}
+
+// WaitForAnyElement waits until one of the selectors matches and returns it
+// Selectors are checked in order on every poll, so earlier ones win ties
+func (b *RodBrowser) WaitForAnyElement(ctx context.Context, selectors []string, timeout time.Duration) (string, error) {
+	if b.page == nil {
+		return "", errors.NewBrowserError("browser page not initialized, call Open first", nil)
+	}
+	
+	var found string
+	err := pollUntil(ctx, timeout, b.options.PollInterval, func() (bool, error) {
+		for _, selector := range selectors {
+			exists, err := b.ElementExists(ctx, selector)
+			if err != nil {
+				return false, err
+			}
+			if exists {
+				found = selector
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return "", errors.NewBrowserError(fmt.Sprintf("timeout waiting for any of: %s", strings.Join(selectors, ", ")), err)
+	}
+	
+	b.log.Debug("Element appeared: %s", found)
+	return found, nil
+}
+
+// pollUntil calls check every interval until it reports true, returns an
+// error, or timeout elapses or ctx is done. The first check runs immediately
+func pollUntil(ctx context.Context, timeout, interval time.Duration, check func() (bool, error)) error {
+	if timeout == 0 {
+		timeout = 10 * time.Second // Default timeout
+	}
+	if interval <= 0 {
+		interval = DefaultBrowserOptions.PollInterval
+	}
+	
+	deadline := time.Now().Add(timeout)
+	for {
+		done, err := check()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		
+		if time.Now().Add(interval).After(deadline) {
+			return fmt.Errorf("condition not met within %v", timeout)
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
 // WaitForNavigation waits for page navigation to complete
-func (b *RodBrowser) WaitForNavigation(timeout time.Duration) error {
+func (b *RodBrowser) WaitForNavigation(ctx context.Context, timeout time.Duration) error {
 	if b.page == nil {
 		return errors.NewBrowserError("browser page not initialized, call Open first", nil)
 	}
@@ -231,8 +355,10 @@ func (b *RodBrowser) WaitForNavigation(timeout time.Duration) error {
 	
 	b.log.Debug("Waiting for page navigation (timeout: %v)...", timeout)
 	
+	page := b.page.Context(ctx)
+	
 	// First try with WaitLoad which is more reliable
-	err := b.page.Timeout(timeout).WaitLoad()
+	err := page.Timeout(timeout).WaitLoad()
 	if err == nil {
 		b.log.Debug("Navigation completed successfully")
 		return nil
@@ -241,7 +367,7 @@ func (b *RodBrowser) WaitForNavigation(timeout time.Duration) error {
 	// If WaitLoad fails, try with WaitIdle as a fallback
 	// This handles cases where the page is still processing after initial load
 	b.log.Debug("WaitLoad failed, trying WaitIdle: %v", err)
-	err = b.page.Timeout(timeout).WaitIdle(timeout)
+	err = page.Timeout(timeout).WaitIdle(timeout)
 	if err == nil {
 		b.log.Debug("Navigation completed with WaitIdle")
 		return nil
@@ -249,10 +375,12 @@ func (b *RodBrowser) WaitForNavigation(timeout time.Duration) error {
 	
 	// As a last resort, just wait a fixed time
 	b.log.Debug("Navigation waiting failed, using fixed delay: %v", err)
-	time.Sleep(timeout / 2)
+	if err := sleepContext(ctx, timeout/2); err != nil {
+		return errors.NewBrowserError("navigation wait cancelled", err)
+	}
 	
 	// Check if we can still interact with the page
-	_, err = b.page.Element("body")
+	_, err = page.Timeout(timeout).Element("body")
 	if err != nil {
 		return errors.NewBrowserError("timeout waiting for navigation", err)
 	}
@@ -262,17 +390,36 @@ func (b *RodBrowser) WaitForNavigation(timeout time.Duration) error {
 }
 
 // ExtractLinks extracts all links (anchor elements) matching the selector
-func (b *RodBrowser) ExtractLinks(selector string) ([]LinkData, error) {
+func (b *RodBrowser) ExtractLinks(ctx context.Context, selector string) ([]LinkData, error) {
 	if b.page == nil {
 		return nil, errors.NewBrowserError("browser page not initialized, call Open first", nil)
 	}
 	
 	// Get elements matching selector
-	elements, err := b.GetElements(selector)
+	elements, err := b.GetElements(ctx, selector)
 	if err != nil {
 		return nil, err
 	}
 	
+	linkElements := make([]linkElement, len(elements))
+	for i, element := range elements {
+		linkElements[i] = element
+	}
+	links := b.readLinks(linkElements)
+	
+	b.log.Debug("Extracted %d links matching selector: %s", len(links), selector)
+	return links, nil
+}
+
+// linkElement is the part of a rod element read by ExtractLinks
+type linkElement interface {
+	Text() (string, error)
+	Attribute(name string) (*string, error)
+}
+
+// readLinks reads the text and attributes of link elements, skipping the
+// ones that detached from the page before they could be read
+func (b *RodBrowser) readLinks(elements []linkElement) []LinkData {
 	var links []LinkData
 	
 	// Process each element
@@ -285,6 +432,9 @@ func (b *RodBrowser) ExtractLinks(selector string) ([]LinkData, error) {
 		text, err := element.Text()
 		if err == nil {
 			link.Text = strings.TrimSpace(text)
+		} else if IsDetachedNodeError(err) {
+			b.log.Debug("Skipping link %d, element detached from the page: %v", i, err)
+			continue
 		} else {
 			b.log.Warn("Could not extract text from link %d: %v", i, err)
 		}
@@ -293,6 +443,9 @@ func (b *RodBrowser) ExtractLinks(selector string) ([]LinkData, error) {
 		href, err := element.Attribute("href")
 		if err == nil && href != nil {
 			link.URL = *href
+		} else if IsDetachedNodeError(err) {
+			b.log.Debug("Skipping link %d, element detached from the page: %v", i, err)
+			continue
 		} else {
 			b.log.Warn("Could not extract href from link %d: %v", i, err)
 		}
@@ -308,6 +461,133 @@ func (b *RodBrowser) ExtractLinks(selector string) ([]LinkData, error) {
 		links = append(links, link)
 	}
 	
-	b.log.Debug("Extracted %d links matching selector: %s", len(links), selector)
-	return links, nil
-}
\ No newline at end of file
+	return links
+}
+
+// PageURL returns the URL of the current page, after any redirects
+func (b *RodBrowser) PageURL(ctx context.Context) (string, error) {
+	if b.page == nil {
+		return "", errors.NewBrowserError("browser page not initialized, call Open first", nil)
+	}
+	
+	info, err := b.page.Context(ctx).Info()
+	if err != nil {
+		return "", errors.NewBrowserError("failed to read the page URL", err)
+	}
+	return info.URL, nil
+}
+
+// Screenshot saves a full-page PNG of the current page to path
+func (b *RodBrowser) Screenshot(ctx context.Context, path string) error {
+	if b.page == nil {
+		return errors.NewBrowserError("browser page not initialized, call Open first", nil)
+	}
+	
+	data, err := b.page.Context(ctx).Screenshot(true, nil)
+	if err != nil {
+		return errors.NewBrowserError("failed to capture screenshot", err)
+	}
+	
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return errors.NewConfigError(fmt.Sprintf("failed to create directory %s", dir), err)
+		}
+	}
+	
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return errors.NewConfigError(fmt.Sprintf("failed to write screenshot %s", path), err)
+	}
+	
+	b.log.Debug("Saved screenshot to %s", path)
+	return nil
+}
+
+// unreachableMessage explains network errors that retrying won't fix
+const unreachableMessage = "cannot reach CAPES, check your network connection or proxy"
+
+// networkUnreachableMessages are the Chromium and Go error fragments reported
+// when the host can't be resolved or reached at all
+var networkUnreachableMessages = []string{
+	"err_name_not_resolved",
+	"err_name_resolution_failed",
+	"err_internet_disconnected",
+	"err_connection_refused",
+	"err_address_unreachable",
+	"err_proxy_connection_failed",
+	"err_tunnel_connection_failed",
+	"no such host",
+	"connection refused",
+	"network is unreachable",
+}
+
+// isNetworkUnreachable reports whether err means CAPES can't be reached at
+// all, e.g. a DNS failure or a refused connection. Unlike timeouts or blocks,
+// these don't recover on retry, so callers should fail fast
+func isNetworkUnreachable(err error) bool {
+	if err == nil {
+		return false
+	}
+	
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range networkUnreachableMessages {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	
+	return false
+}
+
+// launchFailureMessages are the errors reported when the browser executable
+// can't be started at all, e.g. a wrong -chrome-path or a missing install
+var launchFailureMessages = []string{
+	"executable file not found",
+	"no such file or directory",
+	"permission denied",
+	"exec format error",
+}
+
+// isLaunchFailure reports whether err means the browser executable can't be
+// started. Unlike a crash during startup, launching again won't help
+func isLaunchFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range launchFailureMessages {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	
+	return false
+}
+
+// detachedNodeMessages are the CDP error fragments reported when an element
+// was removed from the DOM between being found and being read
+var detachedNodeMessages = []string{
+	"could not find node with given id",
+	"node with given id does not belong to the document",
+	"cannot find context with specified id",
+	"no node with given id found",
+	"node is detached from document",
+}
+
+// IsDetachedNodeError reports whether err was caused by an element that
+// detached from the page. Such errors are recoverable: the element can be
+// skipped while the rest of the page is still processed
+func IsDetachedNodeError(err error) bool {
+	if err == nil {
+		return false
+	}
+	
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range detachedNodeMessages {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	
+	return false
+}
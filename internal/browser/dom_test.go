@@ -0,0 +1,152 @@
+package browser
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alexandreffaria/reviu/internal/logger"
+)
+
+// fakeLink is a link element with scripted text and attributes
+type fakeLink struct {
+	text  string
+	attrs map[string]string
+	err   error // Returned by every read when set
+}
+
+func (l fakeLink) Text() (string, error) {
+	if l.err != nil {
+		return "", l.err
+	}
+	return l.text, nil
+}
+
+func (l fakeLink) Attribute(name string) (*string, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	value, ok := l.attrs[name]
+	if !ok {
+		return nil, nil
+	}
+	return &value, nil
+}
+
+func TestReadLinksSkipsDetachedElements(t *testing.T) {
+	b := &RodBrowser{log: logger.NewLogger(logger.WithWriter(io.Discard))}
+	detached := stderrors.New("{-32000 Could not find node with given id}")
+
+	links := b.readLinks([]linkElement{
+		fakeLink{text: " First ", attrs: map[string]string{"href": "/1", "class": "titulo-busca"}},
+		fakeLink{err: detached},
+		fakeLink{text: "Third", attrs: map[string]string{"href": "/3"}},
+	})
+
+	if len(links) != 2 {
+		t.Fatalf("readLinks() = %v, want the two attached links", links)
+	}
+	if links[0].Text != "First" || links[0].URL != "/1" || links[0].Attributes["class"] != "titulo-busca" {
+		t.Errorf("first link = %+v", links[0])
+	}
+	if links[1].Text != "Third" || links[1].URL != "/3" {
+		t.Errorf("second link = %+v, want the link after the detached one", links[1])
+	}
+}
+
+func TestReadLinksKeepsLinksWithOtherErrors(t *testing.T) {
+	b := &RodBrowser{log: logger.NewLogger(logger.WithWriter(io.Discard))}
+
+	// Errors other than detachment leave the field empty instead of
+	// dropping the link
+	links := b.readLinks([]linkElement{fakeLink{err: stderrors.New("timeout")}})
+	if len(links) != 1 || links[0].Text != "" || links[0].URL != "" {
+		t.Errorf("readLinks() = %v, want one empty link", links)
+	}
+}
+
+func TestIsDetachedNodeError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{stderrors.New("{-32000 Could not find node with given id}"), true},
+		{stderrors.New("Node is detached from document"), true},
+		{stderrors.New("context deadline exceeded"), false},
+	}
+
+	for _, tt := range tests {
+		if got := IsDetachedNodeError(tt.err); got != tt.want {
+			t.Errorf("IsDetachedNodeError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestIsNetworkUnreachable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		// DNS failures, from Chromium and from Go
+		{stderrors.New("{-32000 net::ERR_NAME_NOT_RESOLVED}"), true},
+		{stderrors.New("dial tcp: lookup www.periodicos.capes.gov.br on 127.0.0.53:53: no such host"), true},
+		// Refused connections
+		{stderrors.New("navigation failed: net::ERR_CONNECTION_REFUSED"), true},
+		{stderrors.New("dial tcp 127.0.0.1:9222: connect: connection refused"), true},
+		{stderrors.New("net::ERR_PROXY_CONNECTION_FAILED"), true},
+		// Failures a retry can get past
+		{stderrors.New("net::ERR_TIMED_OUT"), false},
+		{stderrors.New("context deadline exceeded"), false},
+		{stderrors.New("net::ERR_CONNECTION_RESET"), false},
+	}
+
+	for _, tt := range tests {
+		if got := isNetworkUnreachable(tt.err); got != tt.want {
+			t.Errorf("isNetworkUnreachable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+// countedCheck returns a poll check that reports presence on its nth call,
+// counting every call in calls
+func countedCheck(n int, calls *int) func() (bool, error) {
+	return func() (bool, error) {
+		*calls++
+		return *calls == n, nil
+	}
+}
+
+func TestPollUntilRespectsInterval(t *testing.T) {
+	const interval = 20 * time.Millisecond
+
+	calls := 0
+	start := time.Now()
+	if err := pollUntil(context.Background(), time.Second, interval, countedCheck(4, &calls)); err != nil {
+		t.Fatalf("pollUntil() error = %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if calls != 4 {
+		t.Errorf("checked %d times, want 4", calls)
+	}
+	// Three waits between four checks
+	if elapsed < 3*interval || elapsed > 3*interval+500*time.Millisecond {
+		t.Errorf("pollUntil() took %v, want about %v", elapsed, 3*interval)
+	}
+}
+
+func TestPollUntilStopsWithinTimeout(t *testing.T) {
+	// Checks at 0, 50ms and 100ms; another wait would pass the timeout
+	calls := 0
+	err := pollUntil(context.Background(), 120*time.Millisecond, 50*time.Millisecond, countedCheck(-1, &calls))
+	if err == nil {
+		t.Fatal("pollUntil() succeeded for a check that never passes")
+	}
+	if calls != 3 {
+		t.Errorf("checked %d times, want 3 with a 50ms interval in 120ms", calls)
+	}
+}
@@ -0,0 +1,314 @@
+package browser
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Leakless mode is disabled to avoid antivirus false positives, so a crashed
+// run can leave its Chromium process behind. Launched browsers are recorded in
+// a per-user PID file next to the PID of the tool that owns them and the start
+// time and executable of the browser, so a PID reused by an unrelated process
+// is never killed; entries are removed on Close, so whatever remains with a
+// dead owner was leaked by an earlier run. Concurrent runs share the file, so
+// every update holds a lock file next to it.
+
+const (
+	// pidFileDir is the directory, in the user cache dir, holding the PID file
+	pidFileDir = "capes-search"
+	// pidFileName is the name of the file tracking launched browsers
+	pidFileName = "browsers.pid"
+	// pidFileLockTimeout is how long an update waits for another run to
+	// release the PID file. Runs only hold it for a read and a write, so a
+	// lock older than this was left by a run that crashed
+	pidFileLockTimeout = 2 * time.Second
+)
+
+// OrphanProcess is a browser process left running by a run that has exited
+type OrphanProcess struct {
+	PID      int // Browser process ID
+	OwnerPID int // Process ID of the capes-search run that launched it
+
+	identity processIdentity // What the browser process was when launched
+}
+
+// processIdentity tells a process apart from a later one given the same PID
+type processIdentity struct {
+	start string // Start time, in clock ticks since boot
+	exe   string // Path of the executable
+}
+
+// sameProcess reports whether the process now running as o.PID is still the
+// recorded browser, returning an error where that can't be verified
+func (o OrphanProcess) sameProcess() (bool, error) {
+	if o.identity.start == "" {
+		return false, fmt.Errorf("browser process %d was recorded without its start time", o.PID)
+	}
+	current, ok := readProcessIdentity(o.PID)
+	if !ok {
+		return false, fmt.Errorf("can't read the start time of process %d on this system", o.PID)
+	}
+	return current == o.identity, nil
+}
+
+// processTracker keeps the kill functions of the browsers launched by this process
+type processTracker struct {
+	mu      sync.Mutex
+	path    string
+	killers map[int]func()
+}
+
+// tracker records the browsers launched by this process
+var tracker = &processTracker{
+	path:    pidFilePath(),
+	killers: make(map[int]func()),
+}
+
+// pidFilePath returns the PID file of the current user, falling back to the
+// temp dir when there is no cache dir
+func pidFilePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, pidFileDir, pidFileName)
+}
+
+// track records a launched browser process and how to kill its process tree
+func (t *processTracker) track(pid int, kill func()) {
+	if pid <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.killers[pid] = kill
+	identity, _ := readProcessIdentity(pid)
+	t.update(func(entries []OrphanProcess) []OrphanProcess {
+		return append(entries, OrphanProcess{PID: pid, OwnerPID: os.Getpid(), identity: identity})
+	})
+}
+
+// untrack forgets a browser process that was closed normally
+func (t *processTracker) untrack(pid int) {
+	if pid <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.killers, pid)
+	t.removeEntries(func(entry OrphanProcess) bool { return entry.PID == pid })
+}
+
+// removeEntries drops the PID file entries matching drop; callers hold mu
+func (t *processTracker) removeEntries(drop func(OrphanProcess) bool) {
+	t.update(func(entries []OrphanProcess) []OrphanProcess {
+		var kept []OrphanProcess
+		for _, entry := range entries {
+			if !drop(entry) {
+				kept = append(kept, entry)
+			}
+		}
+		return kept
+	})
+}
+
+// update replaces the PID file entries with what change returns, holding the
+// lock file so concurrent runs don't overwrite each other; callers hold mu
+// Tracking is best-effort, so when the lock can't be taken nothing changes
+func (t *processTracker) update(change func([]OrphanProcess) []OrphanProcess) {
+	if err := os.MkdirAll(filepath.Dir(t.path), 0700); err != nil {
+		return
+	}
+	unlock, ok := lockPIDFile(t.path)
+	if !ok {
+		return
+	}
+	defer unlock()
+
+	writePIDFile(t.path, change(readPIDFile(t.path)))
+}
+
+// lockPIDFile takes the lock file guarding the PID file at path, waiting up
+// to pidFileLockTimeout for another run to release it. It reports false when
+// the lock couldn't be taken
+func lockPIDFile(path string) (unlock func(), ok bool) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(pidFileLockTimeout)
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			fmt.Fprintf(file, "%d\n", os.Getpid())
+			file.Close()
+			return func() { os.Remove(lockPath) }, true
+		}
+		if !os.IsExist(err) {
+			return nil, false
+		}
+
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > pidFileLockTimeout {
+			os.Remove(lockPath) // Stale, its run crashed while holding it
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, false
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// KillLaunchedBrowsers kills the process trees of all browsers launched by
+// this process that haven't been closed. It is meant for abnormal exits,
+// such as a panic or an interrupt signal, and returns how many were killed
+func KillLaunchedBrowsers() int {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	killed := 0
+	for pid, kill := range tracker.killers {
+		kill()
+		delete(tracker.killers, pid)
+		killed++
+	}
+
+	owner := os.Getpid()
+	tracker.removeEntries(func(entry OrphanProcess) bool { return entry.OwnerPID == owner })
+	return killed
+}
+
+// FindOrphans returns the browser processes still running whose owning run
+// has exited. Entries for processes that are gone, or whose PID now belongs
+// to another process, are pruned from the PID file
+func FindOrphans() []OrphanProcess {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	var orphans []OrphanProcess
+	tracker.removeEntries(func(entry OrphanProcess) bool {
+		if processAlive(entry.OwnerPID) {
+			return false // Still in use by a running invocation
+		}
+		if !processAlive(entry.PID) {
+			return true // Already gone
+		}
+		if same, err := entry.sameProcess(); err == nil && !same {
+			return true // Gone, and its PID reused
+		}
+		orphans = append(orphans, entry)
+		return false
+	})
+
+	return orphans
+}
+
+// KillOrphans kills the process trees of the given leftover browsers and
+// removes them from the PID file, returning the failures. Each process is
+// checked against its recorded start time and executable first; one that
+// can't be verified is left running
+func KillOrphans(orphans []OrphanProcess) []error {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	var errs []error
+	removed := make(map[int]bool, len(orphans))
+	for _, orphan := range orphans {
+		same, err := orphan.sameProcess()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("not killing browser process %d: %w", orphan.PID, err))
+			continue
+		}
+		removed[orphan.PID] = true
+		if !same {
+			errs = append(errs, fmt.Errorf("not killing process %d: it is no longer the browser launched by run %d", orphan.PID, orphan.OwnerPID))
+			continue
+		}
+		if err := killProcessTree(orphan.PID); err != nil {
+			removed[orphan.PID] = false
+			errs = append(errs, fmt.Errorf("failed to kill browser process %d: %w", orphan.PID, err))
+		}
+	}
+
+	tracker.removeEntries(func(entry OrphanProcess) bool { return removed[entry.PID] })
+	return errs
+}
+
+// processAlive reports whether a process with pid is running
+// Signal 0 isn't supported on Windows, so there this always reports false
+// and leftover processes simply aren't detected
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
+
+// readPIDFile parses "browserPID ownerPID startTime executable" lines,
+// skipping malformed ones. Lines written before the start time and executable
+// were recorded have only the two PIDs
+func readPIDFile(path string) []OrphanProcess {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var entries []OrphanProcess
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// The executable comes last, as its path may contain spaces
+		fields := strings.SplitN(strings.TrimSpace(scanner.Text()), " ", 4)
+		if len(fields) != 2 && len(fields) != 4 {
+			continue
+		}
+
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		owner, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		entry := OrphanProcess{PID: pid, OwnerPID: owner}
+		if len(fields) == 4 {
+			entry.identity = processIdentity{start: fields[2], exe: fields[3]}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// writePIDFile replaces the PID file with entries, removing it when empty
+// Tracking is best-effort, so write failures are ignored
+func writePIDFile(path string, entries []OrphanProcess) {
+	if len(entries) == 0 {
+		os.Remove(path)
+		return
+	}
+
+	var sb strings.Builder
+	for _, entry := range entries {
+		if entry.identity.start == "" {
+			fmt.Fprintf(&sb, "%d %d\n", entry.PID, entry.OwnerPID)
+			continue
+		}
+		fmt.Fprintf(&sb, "%d %d %s %s\n", entry.PID, entry.OwnerPID, entry.identity.start, entry.identity.exe)
+	}
+	os.WriteFile(path, []byte(sb.String()), 0600)
+}
@@ -0,0 +1,67 @@
+package browser
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// useTempPIDFile points the tracker at a PID file in a temp dir for the test
+func useTempPIDFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), pidFileName)
+	previous := tracker.path
+	tracker.path = path
+	t.Cleanup(func() { tracker.path = previous })
+	return path
+}
+
+func TestPIDFileRoundTrip(t *testing.T) {
+	path := useTempPIDFile(t)
+	entries := []OrphanProcess{
+		{PID: 10, OwnerPID: 1},
+		{PID: 20, OwnerPID: 2, identity: processIdentity{start: "12345", exe: "/opt/Google Chrome/chrome"}},
+	}
+
+	writePIDFile(path, entries)
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("PID file not written: %v", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("PID file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	got := readPIDFile(path)
+	if len(got) != 2 || got[0] != entries[0] || got[1] != entries[1] {
+		t.Errorf("readPIDFile() = %v, want %v", got, entries)
+	}
+}
+
+func TestTrackerUpdatesWaitForLock(t *testing.T) {
+	path := useTempPIDFile(t)
+	os.MkdirAll(filepath.Dir(path), 0700)
+
+	// Another run holds the lock while this one records a browser
+	unlock, ok := lockPIDFile(path)
+	if !ok {
+		t.Fatal("lockPIDFile() failed on a free lock")
+	}
+	writePIDFile(path, []OrphanProcess{{PID: 10, OwnerPID: 1}})
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		unlock()
+	}()
+
+	tracker.mu.Lock()
+	tracker.update(func(entries []OrphanProcess) []OrphanProcess {
+		return append(entries, OrphanProcess{PID: 30, OwnerPID: 3})
+	})
+	tracker.mu.Unlock()
+
+	if got := readPIDFile(path); len(got) != 2 {
+		t.Errorf("PID file = %v, want the other run's entry kept", got)
+	}
+}
@@ -0,0 +1,48 @@
+//go:build !windows
+
+package browser
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// readProcessIdentity returns the start time and executable of the process
+// with pid, read from /proc. It reports false where /proc isn't available
+func readProcessIdentity(pid int) (processIdentity, bool) {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return processIdentity{}, false
+	}
+
+	// The command name may contain spaces and parentheses, so fields are
+	// counted after its closing parenthesis; the start time is the 22nd field
+	// of the line, the 20th after the name
+	end := bytes.LastIndexByte(stat, ')')
+	if end < 0 {
+		return processIdentity{}, false
+	}
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 20 {
+		return processIdentity{}, false
+	}
+
+	exe, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid))
+	if err != nil {
+		return processIdentity{}, false
+	}
+	return processIdentity{start: fields[19], exe: exe}, true
+}
+
+// killProcessTree kills the process group led by pid, which is how the
+// launcher starts the browser, so its renderer and helper processes go too
+func killProcessTree(pid int) error {
+	if err := syscall.Kill(-pid, syscall.SIGKILL); err == nil {
+		return nil
+	}
+	// Not a group leader, so kill the process alone
+	return syscall.Kill(pid, syscall.SIGKILL)
+}
@@ -0,0 +1,110 @@
+//go:build !windows
+
+package browser
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// startStub starts a long-running process in its own group, as the launcher
+// starts the browser, and returns it with its recorded identity
+func startStub(t *testing.T) (*exec.Cmd, processIdentity) {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("process identity is read from /proc")
+	}
+
+	cmd := exec.Command("sleep", "60")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		t.Skipf("can't start stub process: %v", err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	identity, ok := readProcessIdentity(cmd.Process.Pid)
+	if !ok {
+		t.Fatalf("readProcessIdentity(%d) failed", cmd.Process.Pid)
+	}
+	return cmd, identity
+}
+
+// exitedPID returns the PID of a process that has already exited, standing
+// in for the owner of a crashed run
+func exitedPID(t *testing.T) int {
+	t.Helper()
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Skipf("can't run stub owner: %v", err)
+	}
+	return cmd.Process.Pid
+}
+
+// waitExited reports whether cmd exits within a few seconds
+func waitExited(cmd *exec.Cmd) bool {
+	done := make(chan struct{})
+	go func() {
+		cmd.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(5 * time.Second):
+		return false
+	}
+}
+
+func TestKillOrphansKillsRecordedBrowser(t *testing.T) {
+	path := useTempPIDFile(t)
+	stub, identity := startStub(t)
+	pid := stub.Process.Pid
+	writePIDFile(path, []OrphanProcess{{PID: pid, OwnerPID: exitedPID(t), identity: identity}})
+
+	orphans := FindOrphans()
+	if len(orphans) != 1 || orphans[0].PID != pid {
+		t.Fatalf("FindOrphans() = %v, want the stub process %d", orphans, pid)
+	}
+
+	if errs := KillOrphans(orphans); len(errs) != 0 {
+		t.Fatalf("KillOrphans() = %v, want no errors", errs)
+	}
+	if !waitExited(stub) {
+		t.Fatal("stub process still running after KillOrphans")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("PID file still exists after its only entry was killed: %v", err)
+	}
+}
+
+func TestKillOrphansSparesReusedPID(t *testing.T) {
+	path := useTempPIDFile(t)
+	stub, identity := startStub(t)
+	pid := stub.Process.Pid
+
+	// An entry recorded for an earlier process that had the same PID
+	reused := OrphanProcess{PID: pid, OwnerPID: exitedPID(t), identity: processIdentity{start: "1", exe: identity.exe}}
+	writePIDFile(path, []OrphanProcess{reused})
+
+	if orphans := FindOrphans(); len(orphans) != 0 {
+		t.Errorf("FindOrphans() = %v, want the reused PID pruned", orphans)
+	}
+
+	writePIDFile(path, []OrphanProcess{reused})
+	if errs := KillOrphans([]OrphanProcess{reused}); len(errs) != 1 {
+		t.Errorf("KillOrphans() = %v, want one error for the reused PID", errs)
+	}
+	if !processAlive(pid) {
+		t.Fatal("KillOrphans killed a process whose start time doesn't match")
+	}
+	if entries := readPIDFile(path); len(entries) != 0 {
+		t.Errorf("PID file = %v, want the stale entry removed", entries)
+	}
+}
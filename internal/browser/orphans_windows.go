@@ -0,0 +1,21 @@
+//go:build windows
+
+package browser
+
+import "os"
+
+// readProcessIdentity always reports false, as Windows has no /proc to read
+// the start time from
+func readProcessIdentity(pid int) (processIdentity, bool) {
+	return processIdentity{}, false
+}
+
+// killProcessTree kills the process with pid; the launcher's own kill function
+// is what takes down the rest of the tree on Windows
+func killProcessTree(pid int) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return process.Kill()
+}
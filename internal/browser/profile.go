@@ -0,0 +1,62 @@
+package browser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/alexandreffaria/reviu/internal/errors"
+)
+
+// A persistent profile (-profile) keeps cookies and local storage between
+// runs. Chromium can't run two browsers on the same profile directory, so
+// the run using it holds a lock file there, naming its PID, until Close.
+
+// profileLockName is the lock file created in a profile directory in use
+const profileLockName = "capes-search.lock"
+
+// lockProfile claims the profile directory dir for this process, creating it
+// if needed. A lock left by a run that has exited is taken over; one held by a
+// running process is a configuration error
+func lockProfile(dir string) (unlock func(), err error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.NewConfigError(fmt.Sprintf("failed to create profile directory %s", dir), err)
+	}
+
+	lockPath := filepath.Join(dir, profileLockName)
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(file, "%d\n", os.Getpid())
+			file.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, errors.NewConfigError(fmt.Sprintf("failed to lock profile directory %s", dir), err)
+		}
+
+		// Another browser of this run or a live run is using the profile
+		owner := readLockOwner(lockPath)
+		if owner == os.Getpid() || processAlive(owner) {
+			return nil, errors.WithCode(errors.NewConfigError(
+				fmt.Sprintf("profile directory %s is in use by another run (PID %d); concurrent runs can't share a profile", dir, owner),
+				nil,
+			), errors.CodeProfileInUse)
+		}
+		os.Remove(lockPath) // Stale, the owner has exited
+	}
+
+	return nil, errors.NewConfigError(fmt.Sprintf("failed to lock profile directory %s", dir), nil)
+}
+
+// readLockOwner returns the PID written in the lock file at path, or 0
+func readLockOwner(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, _ := strconv.Atoi(strings.TrimSpace(string(data)))
+	return pid
+}
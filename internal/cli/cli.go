@@ -3,18 +3,25 @@ package cli
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/alexandreffaria/reviu/internal/config"
 	"github.com/alexandreffaria/reviu/internal/errors"
+	"github.com/alexandreffaria/reviu/internal/i18n"
 	"github.com/alexandreffaria/reviu/internal/logger"
+	"github.com/alexandreffaria/reviu/internal/result"
 )
 
 // CLI handles user interaction via command line
 type CLI struct {
 	reader *bufio.Reader
+	out    io.Writer
+	locale i18n.Locale
 	log    logger.Logger
 }
 
@@ -26,10 +33,35 @@ func NewCLI(log logger.Logger) *CLI {
 
 	return &CLI{
 		reader: bufio.NewReader(os.Stdin),
+		out:    os.Stdout,
+		locale: i18n.DefaultLocale,
 		log:    log.WithPrefix("CLI"),
 	}
 }
 
+// SetLocale selects the language of user-facing messages
+// Unsupported locales keep the current one
+func (c *CLI) SetLocale(locale string) {
+	if i18n.IsSupported(locale) {
+		c.locale = i18n.Locale(locale)
+	} else {
+		c.log.Warn("Unsupported locale '%s', keeping '%s'", locale, c.locale)
+	}
+}
+
+// T returns the message for key in the configured locale
+func (c *CLI) T(key string, args ...interface{}) string {
+	return i18n.Message(c.locale, key, args...)
+}
+
+// SetOutput redirects human-readable messages to the given writer
+// This keeps stdout free for machine-readable output such as the JSON summary
+func (c *CLI) SetOutput(w io.Writer) {
+	if w != nil {
+		c.out = w
+	}
+}
+
 // PromptTextRequired asks for user input with a required value
 func (c *CLI) PromptTextRequired(label, hint string) (string, error) {
 	for {
@@ -40,7 +72,7 @@ func (c *CLI) PromptTextRequired(label, hint string) (string, error) {
 			prompt = fmt.Sprintf("\n%s: ", label)
 		}
 
-		fmt.Print(prompt)
+		fmt.Fprint(c.out, prompt)
 		input, err := c.reader.ReadString('\n')
 		if err != nil {
 			return "", errors.NewUserInputError("failed to read input", err)
@@ -51,7 +83,7 @@ func (c *CLI) PromptTextRequired(label, hint string) (string, error) {
 			return input, nil
 		}
 
-		fmt.Println("Campo obrigatório. Por favor, preencha.")
+		fmt.Fprintln(c.out, c.T("prompt.required"))
 	}
 }
 
@@ -64,7 +96,7 @@ func (c *CLI) EnsureRequiredParameters(params *config.SearchParams) error {
 	// Ensure search term is provided
 	if params.SearchTerm == "" {
 		c.log.Info("Search term not provided via flags, prompting user")
-		term, err := c.PromptTextRequired("TERMOS DE BUSCA", "texto livre (obrigatório)")
+		term, err := c.PromptTextRequired(c.T("prompt.searchTerm"), c.T("prompt.searchTermHint"))
 		if err != nil {
 			return err
 		}
@@ -81,29 +113,23 @@ func (c *CLI) PrintSearchReport(params *config.SearchParams) {
 		return
 	}
 
-	fmt.Println("\n========================================")
-	fmt.Println(" RELATÓRIO DA BUSCA")
-	fmt.Println("========================================")
-	fmt.Printf("Termos de busca:   %s\n", params.SearchTerm)
+	anyValue := c.T("report.any")
+
+	fmt.Fprintln(c.out, "\n========================================")
+	fmt.Fprintln(c.out, c.T("report.title"))
+	fmt.Fprintln(c.out, "========================================")
+	fmt.Fprintf(c.out, "%s%s\n", c.T("report.searchTerm"), params.SearchTerm)
 
 	// Access type
-	if params.AccessType != "" {
-		fmt.Printf("Acesso aberto:     %s\n", params.AccessType)
-	} else {
-		fmt.Printf("Acesso aberto:     qualquer\n")
-	}
+	fmt.Fprintf(c.out, "%s%s\n", c.T("report.access"), valueOr(params.AccessType, anyValue))
 
 	// Publication type
-	if params.PublicationType != "" {
-		fmt.Printf("Tipo de publicação: %s\n", params.PublicationType)
-	} else {
-		fmt.Printf("Tipo de publicação: qualquer\n")
-	}
+	fmt.Fprintf(c.out, "%s%s\n", c.T("report.pubType"), valueOr(strings.Join(params.PublicationTypes, ", "), anyValue))
 
 	// Publication years
 	if params.YearMin > 0 || params.EffectiveYearMax > 0 {
-		anoMinStr := "não especificado"
-		anoMaxStr := "não especificado"
+		anoMinStr := c.T("report.unspecified")
+		anoMaxStr := c.T("report.unspecified")
 
 		if params.YearMin > 0 {
 			anoMinStr = fmt.Sprintf("%d", params.YearMin)
@@ -113,99 +139,198 @@ func (c *CLI) PrintSearchReport(params *config.SearchParams) {
 			anoMaxStr = fmt.Sprintf("%d", params.EffectiveYearMax)
 		}
 
-		fmt.Printf("Anos de publicação: %s até %s\n", anoMinStr, anoMaxStr)
+		fmt.Fprintf(c.out, "%s%s\n", c.T("report.years"), c.T("report.yearRange", anoMinStr, anoMaxStr))
 	} else {
-		fmt.Printf("Anos de publicação: qualquer\n")
+		fmt.Fprintf(c.out, "%s%s\n", c.T("report.years"), anyValue)
 	}
 
 	// Peer review
-	if params.PeerReviewed != "" {
-		fmt.Printf("Revisão por pares:  %s\n", params.PeerReviewed)
-	} else {
-		fmt.Printf("Revisão por pares:  qualquer\n")
-	}
+	fmt.Fprintf(c.out, "%s%s\n", c.T("report.peerReview"), valueOr(params.PeerReviewed, anyValue))
 
 	// Languages
-	if len(params.Languages) > 0 {
-		fmt.Printf("Idiomas:            %s\n", strings.Join(params.Languages, ", "))
-	} else {
-		fmt.Printf("Idiomas:            qualquer\n")
-	}
+	fmt.Fprintf(c.out, "%s%s\n", c.T("report.languages"), valueOr(strings.Join(params.Languages, ", "), anyValue))
+
+	// Knowledge areas
+	fmt.Fprintf(c.out, "%s%s\n", c.T("report.areas"), valueOr(strings.Join(params.SubjectAreas, ", "), anyValue))
+
+	// Source
+	fmt.Fprintf(c.out, "%s%s\n", c.T("report.source"), valueOr(params.Source, c.T("report.all")))
+	fmt.Fprintf(c.out, "%s%s\n", c.T("report.sort"), valueOr(params.SortOrder, c.T("report.sortDefault")))
 
 	// Export information (if enabled)
 	if params.ExportResults && params.OutputFile != "" {
-		fmt.Println("----------------------------------------")
-		fmt.Printf("Exportação de resultados: Habilitada\n")
-		fmt.Printf("Arquivo de saída: %s\n", params.OutputFile)
-		fmt.Printf("Formato: %s\n", params.ExportFormat)
+		fmt.Fprintln(c.out, "----------------------------------------")
+		fmt.Fprintln(c.out, c.T("report.exportEnabled"))
+		fmt.Fprintf(c.out, "%s%s\n", c.T("report.outputFile"), params.OutputFile)
+		format := params.ExportFormat
+		if len(params.ExportFormats) > 0 {
+			format = strings.Join(params.ExportFormats, ", ")
+		}
+		fmt.Fprintf(c.out, "%s%s\n", c.T("report.format"), format)
 		
 		if params.MaxPages > 0 {
-			fmt.Printf("Máximo de páginas: %d\n", params.MaxPages)
+			fmt.Fprintf(c.out, "%s%d\n", c.T("report.maxPages"), params.MaxPages)
 		} else {
-			fmt.Printf("Máximo de páginas: todas\n")
+			fmt.Fprintf(c.out, "%s%s\n", c.T("report.maxPages"), c.T("report.all"))
 		}
 		
-		fmt.Printf("Incluir cabeçalhos: %v\n", params.IncludeHeaders)
+		if params.ExtractAbstracts {
+			if params.AbstractsOpenAccessOnly {
+				fmt.Fprintf(c.out, "%s%s\n", c.T("report.abstracts"), c.T("report.abstractsOAOnly"))
+			} else {
+				fmt.Fprintf(c.out, "%s%s\n", c.T("report.abstracts"), c.T("report.yes"))
+			}
+		}
+		
+		if params.MaxResults > 0 {
+			fmt.Fprintf(c.out, "%s%d\n", c.T("report.maxResults"), params.MaxResults)
+		}
+		
+		if params.SinceLastRun {
+			fmt.Fprintf(c.out, "%s%s\n", c.T("report.since"), c.T("report.sinceLastRun"))
+		} else if !params.Since.IsZero() {
+			fmt.Fprintf(c.out, "%s%s\n", c.T("report.since"), params.SinceSpec)
+		}
+		
+		if params.SkipDetails {
+			fmt.Fprintf(c.out, "%s%s\n", c.T("report.details"), c.T("report.noDetails"))
+		}
+		
+		fmt.Fprintf(c.out, "%s%v\n", c.T("report.includeHeaders"), params.IncludeHeaders)
 		
 		// Show page delay if set
 		if params.PageDelay > 0 {
-			fmt.Printf("Delay entre páginas: %v\n", params.PageDelay)
+			fmt.Fprintf(c.out, "%s%v\n", c.T("report.pageDelay"), params.PageDelay)
+		}
+		if params.DetailDelay > 0 {
+			fmt.Fprintf(c.out, "%s%v\n", c.T("report.detailDelay"), params.DetailDelay)
 		}
 	}
-	fmt.Println("========================================")
+	fmt.Fprintln(c.out, "========================================")
+}
+
+// valueOr returns value, or fallback when value is empty
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
 }
 
 // PrintSearchURL prints the generated search URL
 func (c *CLI) PrintSearchURL(url string) {
-	fmt.Println("URL da busca:", url)
+	fmt.Fprintln(c.out, c.T("search.url"), url)
+}
+
+// PrintCheck prints one line of a pass/fail report, in green or red
+func (c *CLI) PrintCheck(name string, ok bool, detail string) {
+	if ok {
+		fmt.Fprintf(c.out, "  \033[32m[OK]\033[0m    %s: %s\n", name, detail)
+	} else {
+		fmt.Fprintf(c.out, "  \033[31m[%s]\033[0m %s: %s\n", c.T("check.failed"), name, detail)
+	}
 }
 
 // PrintBrowserInfo prints information about the browser status
 func (c *CLI) PrintBrowserInfo(message string) {
-	fmt.Println(message)
+	fmt.Fprintln(c.out, message)
 }
 
-// PrintExportStatus prints status updates during the export process
-func (c *CLI) PrintExportStatus(currentPage int, totalResults int, filename string) {
-	fmt.Printf("Processando página %d... (%d resultados encontrados até agora)\r", currentPage, totalResults)
+// PrintExportStatus prints a progress bar line for the export, ending in a
+// carriage return so the next update redraws it in place
+func (c *CLI) PrintExportStatus(currentPage int, totalPages int, totalResults int, eta time.Duration) {
+	bar, percent := renderBar(currentPage, totalPages)
+	fmt.Fprint(c.out, c.T("export.progress", bar, percent, currentPage, totalPages, totalResults,
+		eta.Round(time.Second)))
 }
 
-// PrintExportCompletion prints the final export status
-func (c *CLI) PrintExportCompletion(totalPages int, totalResults int, filename string, duration string) {
-	fmt.Printf("\nExportação concluída:\n")
-	fmt.Printf("- Páginas processadas: %d\n", totalPages)
-	fmt.Printf("- Resultados exportados: %d\n", totalResults)
-	fmt.Printf("- Arquivo salvo em: %s\n", filename)
-	fmt.Printf("- Tempo total: %s\n", duration)
+// PrintExportCompletion prints the final export status, with the throughput
+// as a guide to how long similar searches will take
+func (c *CLI) PrintExportCompletion(totalPages int, stats result.ExportStats) {
+	fmt.Fprintf(c.out, "\n%s\n", c.T("export.completed"))
+	fmt.Fprintln(c.out, c.T("export.pages", totalPages))
+	fmt.Fprintln(c.out, c.T("export.results", stats.ResultsWritten))
+	for _, file := range stats.Files {
+		fmt.Fprintln(c.out, c.T("export.file", file.Path, formatBytes(file.Bytes)))
+	}
+	fmt.Fprintln(c.out, c.T("export.duration", stats.Duration.Round(time.Second)))
+	fmt.Fprintln(c.out, c.T("export.throughput", stats.ResultsPerMinute()))
+	if stats.ErrorCount > 0 {
+		fmt.Fprintln(c.out, c.T("export.errors", stats.ErrorCount))
+	}
+}
+
+// formatBytes returns n in the largest unit that keeps it at least 1
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, exp := float64(n)/unit, 0
+	for value >= unit && exp < 2 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", value, "KMG"[exp])
+}
+
+// PrintJSONSummary writes v as a single JSON object on stdout
+func (c *CLI) PrintJSONSummary(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return errors.NewExternalError("failed to write JSON summary", err)
+	}
+	return nil
+}
+
+// usageSection is a group of flags in the usage output; the flag names are
+// padded to width so their descriptions line up
+type usageSection struct {
+	title string
+	width int
+	flags []string
+}
+
+// usageSections lists the flags shown by PrintUsage, each described by the
+// "usage.flag.<name>" message
+var usageSections = []usageSection{
+	{"usage.search", 9, []string{
+		"search", "oa", "t", "pymin", "pymax", "pr", "lang", "area", "source", "query-mode", "sort", "config",
+	}},
+	{"usage.export", 11, []string{
+		"output", "format", "max-pages", "page-range", "no-headers", "max-results", "abstracts", "abstracts-oa-only", "no-details",
+		"normalize-authors", "author-separator", "title-dedup-distance", "author", "since", "dump-url-list", "pages", "on-existing",
+		"append", "columns", "bom", "delimiter", "quote-all", "compress", "no-export-on-empty", "min-results", "stream", "resume",
+		"json-summary",
+	}},
+	{"usage.interface", 11, []string{
+		"lang-ui", "quiet", "view-time", "log-format", "log-level", "log-file", "error-json",
+	}},
+	{"usage.diagnostics", 11, []string{
+		"preflight", "preflight-only", "dry-run", "count", "screenshot-on-empty", "version",
+	}},
+	{"usage.antiBlock", 11, []string{
+		"delay", "detail-delay", "headless", "stealth", "random-ua", "ua-file", "slow", "abort-on-block", "throttle-factor",
+		"throttle-max-delay", "retry-initial-delay", "retry-max-delay", "deadline", "pause-file", "poll-interval",
+		"element-timeout", "chrome-path", "profile", "window", "kill-orphans", "retry-on-empty-page",
+	}},
 }
 
 // PrintUsage prints help information about command-line flags
 func (c *CLI) PrintUsage() {
-	fmt.Println("\nUso: capes-search [flags]")
-	
-	fmt.Println("\nFlags de busca:")
-	fmt.Println("  -search   Termo de busca (ex: 'inteligência artificial')")
-	fmt.Println("  -oa       Acesso aberto: 'sim', 'nao' ou omitir para qualquer")
-	fmt.Println("  -t        Tipo de publicação (ex: 'Artigo')")
-	fmt.Println("  -pymin    Ano mínimo de publicação (ex: 2010)")
-	fmt.Println("  -pymax    Ano máximo de publicação (ex: 2023)")
-	fmt.Println("  -pr       Revisão por pares: 'sim', 'nao' ou omitir para qualquer")
-	fmt.Println("  -lang     Idiomas separados por '/' (ex: 'Português/Inglês')")
+	fmt.Fprintf(c.out, "\n%s\n", c.T("usage.title"))
 	
-	fmt.Println("\nFlags de exportação:")
-	fmt.Println("  -output     Arquivo para salvar os resultados (ex: 'resultados.csv')")
-	fmt.Println("  -format     Formato de exportação (atualmente apenas 'csv')")
-	fmt.Println("  -max-pages  Número máximo de páginas a processar (0 = todas)")
-	fmt.Println("  -no-headers Não incluir cabeçalhos no arquivo CSV")
-	
-	fmt.Println("\nFlags de proteção anti-bloqueio:")
-	fmt.Println("  -delay      Espera entre páginas para evitar bloqueio (ex: '5s', '10s')")
-	fmt.Println("  -stealth    Ativa modo stealth para evitar detecção (padrão: true)")
-	fmt.Println("  -random-ua  Usa agente de usuário aleatório (padrão: true)")
+	for _, section := range usageSections {
+		fmt.Fprintf(c.out, "\n%s\n", c.T(section.title))
+		for _, name := range section.flags {
+			fmt.Fprintf(c.out, "  %-*s %s\n", section.width, "-"+name, c.T("usage.flag."+name))
+		}
+	}
 	
-	fmt.Println("\nExemplos:")
-	fmt.Println("  capes-search -search \"violência contra mulheres\"")
-	fmt.Println("  capes-search -search \"inteligência artificial\" -oa sim -output \"resultados.csv\"")
-	fmt.Println("  capes-search -search \"vacinas\" -pr sim -lang \"Português/Inglês\" -max-pages 5 -output \"vacinas.csv\"")
-	fmt.Println("  capes-search -search \"machine learning\" -delay 5s -output \"ml_results.csv\"")
+	fmt.Fprintf(c.out, "\n%s\n", c.T("usage.examples"))
+	fmt.Fprintln(c.out, "  capes-search -search \"violência contra mulheres\"")
+	fmt.Fprintln(c.out, "  capes-search -search \"inteligência artificial\" -oa sim -output \"resultados.csv\"")
+	fmt.Fprintln(c.out, "  capes-search -search \"vacinas\" -pr sim -lang \"Português/Inglês\" -max-pages 5 -output \"vacinas.csv\"")
+	fmt.Fprintln(c.out, "  capes-search -search \"machine learning\" -delay 5s -output \"ml_results.csv\"")
 }
\ No newline at end of file
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/alexandreffaria/reviu/internal/config"
+	"github.com/alexandreffaria/reviu/internal/i18n"
+	"github.com/alexandreffaria/reviu/internal/logger"
+)
+
+// renderReport returns the search report for params printed in locale
+func renderReport(locale string, params *config.SearchParams) string {
+	var out bytes.Buffer
+	c := NewCLI(logger.NewLogger(logger.WithWriter(io.Discard)))
+	c.SetOutput(&out)
+	c.SetLocale(locale)
+	c.PrintSearchReport(params)
+	return out.String()
+}
+
+func TestPrintSearchReportLocales(t *testing.T) {
+	params := config.NewSearchParams()
+	params.SearchTerm = "soil carbon"
+	params.YearMin = 2015
+	params.EffectiveYearMax = 2020
+	params.ExportResults = true
+	params.OutputFile = "resultados.csv"
+	params.ExportFormat = "csv"
+
+	reports := map[i18n.Locale]string{
+		i18n.LocalePT: renderReport("pt", params),
+		i18n.LocaleEN: renderReport("en", params),
+	}
+	if reports[i18n.LocalePT] == reports[i18n.LocaleEN] {
+		t.Fatal("report is the same in both locales")
+	}
+
+	for locale, report := range reports {
+		// Labels follow the locale, values stay as given
+		for _, line := range []string{
+			i18n.Message(locale, "report.title"),
+			i18n.Message(locale, "report.searchTerm") + "soil carbon",
+			i18n.Message(locale, "report.years") + i18n.Message(locale, "report.yearRange", "2015", "2020"),
+			i18n.Message(locale, "report.outputFile") + "resultados.csv",
+		} {
+			if !strings.Contains(report, line+"\n") {
+				t.Errorf("%s report is missing line %q:\n%s", locale, line, report)
+			}
+		}
+	}
+
+	if strings.Contains(reports[i18n.LocaleEN], i18n.Message(i18n.LocalePT, "report.searchTerm")) {
+		t.Error("English report still has the Portuguese label")
+	}
+}
+
+func TestSetLocaleKeepsCurrentOnUnsupported(t *testing.T) {
+	params := config.NewSearchParams()
+	params.SearchTerm = "soil"
+
+	if got, want := renderReport("de", params), renderReport("pt", params); got != want {
+		t.Errorf("report for an unsupported locale = %q, want the default locale's %q", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"strings"
+	"time"
+)
+
+// progressBarWidth is the number of cells in the progress bar
+const progressBarWidth = 30
+
+// ProgressBar renders export progress on a single terminal line, redrawn in
+// place with a carriage return after every page
+type ProgressBar struct {
+	cli       *CLI
+	start     time.Time
+	firstPage int
+}
+
+// NewProgressBar creates a progress bar that starts timing now
+func (c *CLI) NewProgressBar() *ProgressBar {
+	return &ProgressBar{cli: c, start: time.Now()}
+}
+
+// Update redraws the bar after currentPage of totalPages was processed
+// Its signature matches the extractor's progress callback
+func (p *ProgressBar) Update(currentPage, totalPages, totalResults int) {
+	if p.firstPage == 0 {
+		p.firstPage = currentPage
+	}
+
+	// The ETA assumes the remaining pages take as long as the ones so far
+	var eta time.Duration
+	if done := currentPage - p.firstPage + 1; done > 0 && totalPages > currentPage {
+		perPage := time.Since(p.start) / time.Duration(done)
+		eta = perPage * time.Duration(totalPages-currentPage)
+	}
+
+	p.cli.PrintExportStatus(currentPage, totalPages, totalResults, eta)
+}
+
+// Done moves past the progress line so later output starts on a new line
+func (p *ProgressBar) Done() {
+	if p.firstPage != 0 {
+		p.cli.PrintBrowserInfo("")
+	}
+}
+
+// renderBar draws a bar of progressBarWidth cells for current out of total
+func renderBar(current, total int) (string, int) {
+	if total <= 0 {
+		return "[" + strings.Repeat(" ", progressBarWidth) + "]", 0
+	}
+	if current > total {
+		current = total
+	}
+
+	filled := current * progressBarWidth / total
+	percent := current * 100 / total
+	return "[" + strings.Repeat("#", filled) + strings.Repeat(".", progressBarWidth-filled) + "]", percent
+}
@@ -0,0 +1,269 @@
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/alexandreffaria/reviu/internal/errors"
+)
+
+// configKeys maps SearchParams field names, as used in config files, to the
+// flag that sets them. Keys may also be flag names, and camelCase keys fall
+// back to the matching kebab-case flag ("maxPages" sets -max-pages)
+var configKeys = map[string]string{
+	"searchTerm":              searchTermFlag,
+	"accessType":              accessTypeFlag,
+	"publicationType":         publicationTypeFlag,
+	"publicationTypes":        publicationTypeFlag,
+	"yearMin":                 yearMinFlag,
+	"yearMax":                 yearMaxFlag,
+	"peerReviewed":            peerReviewedFlag,
+	"languages":               languagesFlag,
+	"subjectAreas":            subjectAreaFlag,
+	"areas":                   subjectAreaFlag,
+	"outputFile":              outputFileFlag,
+	"exportFormat":            formatFlag,
+	"urlListFile":             dumpURLListFlag,
+	"extractAbstracts":        abstractsFlag,
+	"abstractsOpenAccessOnly": abstractsOAOnlyFlag,
+	"stealthMode":             stealthModeFlag,
+	"randomUserAgent":         randomUserAgentFlag,
+	"slowMotion":              slowMotionFlag,
+	"pageDelay":               pageDelayFlag,
+	"throttleMaxDelay":        throttleMaxFlag,
+}
+
+// applyConfigFile sets every flag named in the config file at path that was
+// not passed explicitly on the command line, so flags override the file
+func applyConfigFile(path string) error {
+	values, err := LoadConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	// Snapshot the explicit flags before the file starts setting them
+	explicit := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := configFlagName(key)
+		if name == "" || name == configFlag {
+			return errors.NewConfigError(fmt.Sprintf("unknown key %q in config file %s", key, path), nil)
+		}
+		if explicit[name] {
+			continue
+		}
+
+		items, err := configValue(values[key])
+		if err != nil {
+			return errors.NewConfigError(fmt.Sprintf("invalid value for %q in config file %s", key, path), err)
+		}
+		if len(items) == 0 {
+			continue
+		}
+
+		// List flags take each item as a separate occurrence; the others
+		// get the slash-separated form
+		values := []string{strings.Join(items, "/")}
+		if _, ok := flag.Lookup(name).Value.(*listFlag); ok {
+			values = items
+		}
+		for _, value := range values {
+			if err := flag.Set(name, value); err != nil {
+				return errors.NewConfigError(fmt.Sprintf("invalid value for %q in config file %s", key, path), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// configFlagName returns the flag set by a config file key, or "" if none
+func configFlagName(key string) string {
+	if name, ok := configKeys[key]; ok {
+		return name
+	}
+	if flag.Lookup(key) != nil {
+		return key
+	}
+	if name := kebabCase(key); flag.Lookup(name) != nil {
+		return name
+	}
+	return ""
+}
+
+// kebabCase converts "maxPages" to "max-pages"
+func kebabCase(s string) string {
+	var sb strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// configValue converts a decoded config value to flag values
+func configValue(value any) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case bool:
+		return []string{strconv.FormatBool(v)}, nil
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			converted, err := configValue(item)
+			if err != nil {
+				return nil, err
+			}
+			if len(converted) != 1 {
+				return nil, fmt.Errorf("lists may only contain plain values")
+			}
+			items = append(items, converted...)
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("unsupported value %v", value)
+	}
+}
+
+// LoadConfigFile reads a search definition from a JSON (.json) or YAML
+// (.yaml, .yml) file into a map from key to value
+func LoadConfigFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewConfigError(fmt.Sprintf("cannot read config file %s", path), err)
+	}
+
+	var values map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &values)
+	case ".yaml", ".yml":
+		values, err = parseYAML(string(data))
+	default:
+		return nil, errors.NewConfigError(
+			fmt.Sprintf("unsupported config file %s (must end in .json, .yaml or .yml)", path), nil)
+	}
+	if err != nil {
+		return nil, errors.NewConfigError(fmt.Sprintf("cannot parse config file %s", path), err)
+	}
+
+	return values, nil
+}
+
+// parseYAML parses the flat subset of YAML search definitions need:
+// "key: value" pairs, comments, quoted strings and lists written either
+// inline ("[a, b]") or as "- item" lines under an empty key
+func parseYAML(data string) (map[string]any, error) {
+	values := make(map[string]any)
+	listKey := ""
+
+	for i, rawLine := range strings.Split(data, "\n") {
+		line := strings.TrimRight(stripYAMLComment(rawLine), " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+
+		// List item belonging to the last empty key
+		if trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+			if listKey == "" {
+				return nil, fmt.Errorf("line %d: list item without a key", i+1)
+			}
+			items := values[listKey].([]any)
+			values[listKey] = append(items, unquoteYAML(strings.TrimSpace(trimmed[1:])))
+			continue
+		}
+
+		if line[0] == ' ' || line[0] == '\t' {
+			return nil, fmt.Errorf("line %d: nested values are not supported", i+1)
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected 'key: value'", i+1)
+		}
+		key = unquoteYAML(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		listKey = ""
+		switch {
+		case value == "":
+			values[key] = []any{}
+			listKey = key
+		case strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]"):
+			items := []any{}
+			for _, item := range strings.Split(value[1:len(value)-1], ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, unquoteYAML(item))
+				}
+			}
+			values[key] = items
+		default:
+			values[key] = unquoteYAML(value)
+		}
+	}
+
+	return values, nil
+}
+
+// stripYAMLComment removes a "#" comment that is not inside quotes
+func stripYAMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// unquoteYAML removes the quotes around a YAML scalar
+func unquoteYAML(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+
+	switch {
+	case s[0] == '"' && s[len(s)-1] == '"':
+		if unquoted, err := strconv.Unquote(s); err == nil {
+			return unquoted
+		}
+		return s[1 : len(s)-1]
+	case s[0] == '\'' && s[len(s)-1] == '\'':
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'")
+	}
+	return s
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/alexandreffaria/reviu/internal/logger"
+)
+
+// setupFlags registers the flags once; they live on the global flag set, so
+// a second SetupFlags would panic when tests run with -count above 1
+var setupFlags = sync.OnceFunc(func() {
+	SetupFlags(logger.NewLogger(logger.WithWriter(io.Discard)))
+})
+
+func TestWriteFlagsJSON(t *testing.T) {
+	setupFlags()
+
+	var out bytes.Buffer
+	if err := WriteFlagsJSON(&out); err != nil {
+		t.Fatalf("WriteFlagsJSON() error = %v", err)
+	}
+	var flags []FlagInfo
+	if err := json.Unmarshal(out.Bytes(), &flags); err != nil {
+		t.Fatalf("WriteFlagsJSON() wrote invalid JSON: %v\n%s", err, out.String())
+	}
+	byName := make(map[string]FlagInfo, len(flags))
+	for _, info := range flags {
+		byName[info.Name] = info
+	}
+
+	tests := []struct {
+		name, typ, def string
+	}{
+		{searchTermFlag, "string", ""},
+		{formatFlag, "string", "csv"},
+		{yearMinFlag, "int", "0"},
+		{maxPagesFlag, "int", "0"},
+		{jsonSummaryFlag, "bool", "false"},
+		{pageDelayFlag, "duration", "2s"},
+		{publicationTypeFlag, "list", ""},
+		{languagesFlag, "list", ""},
+	}
+	for _, tt := range tests {
+		info, ok := byName[tt.name]
+		if !ok {
+			t.Errorf("flag -%s missing from the JSON", tt.name)
+			continue
+		}
+		if info.Type != tt.typ || info.Default != tt.def {
+			t.Errorf("flag -%s = type %q default %q, want %q and %q", tt.name, info.Type, info.Default, tt.typ, tt.def)
+		}
+		if info.Usage == "" {
+			t.Errorf("flag -%s has no usage text", tt.name)
+		}
+	}
+
+	if _, ok := byName[listFlagsJSONFlag]; ok {
+		t.Errorf("hidden flag -%s listed in the JSON", listFlagsJSONFlag)
+	}
+}
@@ -2,10 +2,19 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/alexandreffaria/reviu/internal/errors"
+	"github.com/alexandreffaria/reviu/internal/i18n"
+	"github.com/alexandreffaria/reviu/internal/logger"
+	"github.com/alexandreffaria/reviu/internal/textnorm"
 )
 
 // Validator provides methods to validate search parameters
@@ -14,7 +23,16 @@ type Validator interface {
 }
 
 // DefaultValidator implements parameter validation logic
-type DefaultValidator struct{}
+type DefaultValidator struct {
+	// Log receives warnings about values that were accepted but may not match
+	// anything on the portal. It is optional.
+	Log logger.Logger
+	
+	// Columns lists the export column names -columns accepts. The columns
+	// are defined next to the writers, so the caller provides them; when
+	// empty, column names are not checked
+	Columns []string
+}
 
 // ValidateSearchParams validates all search parameters
 // Returns error if validation fails
@@ -23,11 +41,41 @@ func (v *DefaultValidator) ValidateSearchParams(params *SearchParams) error {
 		return errors.NewConfigError("params cannot be nil", nil)
 	}
 	
+	// A config file that couldn't be applied invalidates everything in it
+	if params.configErr != nil {
+		return params.configErr
+	}
+	
 	// Required parameter validation
 	if params.SearchTerm == "" {
 		return errors.NewConfigError("search term is required", nil)
 	}
 	
+	// Validate message locale
+	if err := validateLocale(params); err != nil {
+		return err
+	}
+	
+	// Validate log format
+	if err := validateLogFormat(params); err != nil {
+		return err
+	}
+	
+	// Validate how long view mode stays open
+	if params.ViewTime < 0 {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid view time: %v (must be 0 or positive)", params.ViewTime),
+			nil,
+		)
+	}
+	
+	// Validate log level
+	if params.LogLevel != "" {
+		if _, err := logger.ParseLevel(params.LogLevel); err != nil {
+			return errors.NewUserInputError("invalid -log-level", err)
+		}
+	}
+	
 	// Validate and normalize access type
 	if err := validateAccessType(params); err != nil {
 		return err
@@ -38,19 +86,90 @@ func (v *DefaultValidator) ValidateSearchParams(params *SearchParams) error {
 		return err
 	}
 	
+	// Validate result ordering
+	if err := validateSortOrder(params); err != nil {
+		return err
+	}
+	
+	// Validate how the search term is sent
+	if err := validateQueryMode(params); err != nil {
+		return err
+	}
+	
 	// Validate publication years
 	if err := validateYears(params); err != nil {
-		return err
+		return errors.WithCode(err, errors.CodeInvalidYear)
 	}
 	
 	// Normalize languages
-	normalizeLanguages(params)
+	normalizeLanguages(params, v.Log)
+	
+	// Validate knowledge areas
+	if err := validateSubjectAreas(params); err != nil {
+		return err
+	}
+	
+	// Validate and normalize publication types
+	if err := validatePublicationTypes(params); err != nil {
+		return err
+	}
+	
+	// Validate page range, used by both exports and URL lists
+	if err := validatePageRange(params); err != nil {
+		return errors.WithCode(err, errors.CodeInvalidPageRange)
+	}
+	
+	// Validate page count for the URL list, which runs without an export
+	if params.Pages < 0 {
+		return errors.WithCode(errors.NewConfigError(
+			fmt.Sprintf("invalid pages value: %d (must be 0 or positive)", params.Pages),
+			nil,
+		), errors.CodeInvalidPageRange)
+	}
+	
+	// Validate the proxy before it reaches the browser launcher
+	if err := validateProxy(params); err != nil {
+		return errors.WithCode(err, errors.CodeInvalidProxy)
+	}
+	
+	// A missing executable would otherwise only fail at launch
+	if err := validateChromePath(params); err != nil {
+		return errors.WithCode(err, errors.CodeBrowserNotFound)
+	}
+	
+	// A file in place of the profile would only fail at launch
+	if err := validateProfileDir(params); err != nil {
+		return err
+	}
+	
+	// An empty list would only fail at launch
+	if err := validateUserAgentFile(params); err != nil {
+		return err
+	}
+	
+	// Resolve the window size
+	if err := validateWindowSize(params); err != nil {
+		return err
+	}
+	
+	// Resolve the run deadline
+	if err := validateDeadline(params, time.Now()); err != nil {
+		return errors.WithCode(err, errors.CodeInvalidDeadline)
+	}
+	
+	// Resolve the date incremental exports keep results from
+	if err := validateSince(params, time.Now()); err != nil {
+		return errors.WithCode(err, errors.CodeInvalidSince)
+	}
 	
 	// Validate export parameters if export is enabled
 	if params.ExportResults {
 		if err := validateExportParams(params); err != nil {
 			return err
 		}
+		if err := validateColumns(params, v.Columns); err != nil {
+			return errors.WithCode(err, errors.CodeInvalidColumns)
+		}
 	}
 	
 	// Mark params as validated
@@ -59,6 +178,173 @@ func (v *DefaultValidator) ValidateSearchParams(params *SearchParams) error {
 	return nil
 }
 
+// validateLocale validates the message locale, defaulting to Portuguese
+func validateLocale(params *SearchParams) error {
+	if params.Locale == "" {
+		params.Locale = string(i18n.DefaultLocale)
+		return nil
+	}
+	
+	if i18n.IsSupported(params.Locale) {
+		return nil
+	}
+	
+	return errors.NewConfigError(
+		fmt.Sprintf("invalid locale: %s (must be 'pt' or 'en')", params.Locale),
+		nil,
+	)
+}
+
+// validateLogFormat checks LogFormat, defaulting an empty one to "text"
+func validateLogFormat(params *SearchParams) error {
+	switch params.LogFormat {
+	case "":
+		params.LogFormat = "text"
+	case "text", "json":
+	default:
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid log format: %s (must be 'text' or 'json')", params.LogFormat),
+			nil,
+		)
+	}
+	return nil
+}
+
+// validatePageRange parses PageRange ("A-B", or "A" for a single page) into
+// PageRangeStart and PageRangeEnd
+func validatePageRange(params *SearchParams) error {
+	params.PageRangeStart, params.PageRangeEnd = 0, 0
+	if params.PageRange == "" {
+		return nil
+	}
+	
+	start, end, err := parsePageRange(params.PageRange)
+	if err != nil {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid page range: %s (use 'A-B' with 1 <= A <= B)", params.PageRange),
+			err,
+		)
+	}
+	
+	params.PageRangeStart, params.PageRangeEnd = start, end
+	return nil
+}
+
+// parsePageRange parses "A-B" or "A" into a validated, 1-based page range
+func parsePageRange(spec string) (int, int, error) {
+	startText, endText, isRange := strings.Cut(spec, "-")
+	if !isRange {
+		endText = startText
+	}
+	
+	start, err := strconv.Atoi(strings.TrimSpace(startText))
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := strconv.Atoi(strings.TrimSpace(endText))
+	if err != nil {
+		return 0, 0, err
+	}
+	
+	if start < 1 || end < 1 {
+		return 0, 0, fmt.Errorf("pages must be positive")
+	}
+	if start > end {
+		return 0, 0, fmt.Errorf("first page %d is after last page %d", start, end)
+	}
+	
+	return start, end, nil
+}
+
+// validateDeadline resolves DeadlineSpec into an absolute Deadline
+// The spec is either a duration relative to now ("90m"), a time of day
+// ("06:00", the next occurrence) or an RFC 3339 timestamp
+func validateDeadline(params *SearchParams, now time.Time) error {
+	params.Deadline = time.Time{}
+	if params.DeadlineSpec == "" {
+		return nil
+	}
+	
+	deadline, err := parseDeadline(params.DeadlineSpec, now)
+	if err != nil {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid deadline: %s (use a duration like '2h', a time like '06:00' or RFC 3339)", params.DeadlineSpec),
+			err,
+		)
+	}
+	
+	if !deadline.After(now) {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid deadline: %s is already in the past", params.DeadlineSpec),
+			nil,
+		)
+	}
+	
+	params.Deadline = deadline
+	return nil
+}
+
+// parseDeadline converts a deadline spec into an absolute time
+func parseDeadline(spec string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(spec); err == nil {
+		return now.Add(d), nil
+	}
+	
+	if t, err := time.ParseInLocation("15:04", spec, now.Location()); err == nil {
+		deadline := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if !deadline.After(now) {
+			deadline = deadline.AddDate(0, 0, 1)
+		}
+		return deadline, nil
+	}
+	
+	return time.Parse(time.RFC3339, spec)
+}
+
+// sinceLastRun is the -since value that continues from the last recorded run
+const sinceLastRun = "last"
+
+// sinceLayouts are the date forms accepted by -since, most precise first
+var sinceLayouts = []string{"2006-01-02", "2006-01", "2006"}
+
+// validateSince resolves SinceSpec into the date results are kept from
+// With "last" the date is only known once the run record is read, at export
+func validateSince(params *SearchParams, now time.Time) error {
+	params.Since = time.Time{}
+	params.SinceLastRun = false
+	if params.SinceSpec == "" {
+		return nil
+	}
+	
+	// Earlier runs are recorded next to the output file
+	if params.OutputFile == "" {
+		return errors.NewConfigError("-since requires -output, next to which the runs are recorded", nil)
+	}
+	
+	if strings.EqualFold(params.SinceSpec, sinceLastRun) {
+		params.SinceLastRun = true
+		return nil
+	}
+	
+	for _, layout := range sinceLayouts {
+		if since, err := time.ParseInLocation(layout, params.SinceSpec, now.Location()); err == nil {
+			if since.After(now) {
+				return errors.NewConfigError(
+					fmt.Sprintf("invalid -since date: %s is in the future", params.SinceSpec),
+					nil,
+				)
+			}
+			params.Since = since
+			return nil
+		}
+	}
+	
+	return errors.NewConfigError(
+		fmt.Sprintf("invalid -since date: %s (use 'last', a year like '2024' or a date like '2024-03-01')", params.SinceSpec),
+		nil,
+	)
+}
+
 // validateAccessType validates and normalizes the access type parameter
 func validateAccessType(params *SearchParams) error {
 	if params.AccessType == "" {
@@ -75,6 +361,286 @@ func validateAccessType(params *SearchParams) error {
 	return nil
 }
 
+// SortOrders lists the accepted -sort values
+var SortOrders = []string{"relevance", "date_desc", "date_asc", "title"}
+
+// validateSortOrder validates the result ordering
+func validateSortOrder(params *SearchParams) error {
+	if params.SortOrder == "" || slices.Contains(SortOrders, params.SortOrder) {
+		return nil // Empty value keeps the portal's ordering
+	}
+	
+	return errors.NewConfigError(
+		fmt.Sprintf("invalid sort order: %s (must be one of: %s)", params.SortOrder, strings.Join(SortOrders, ", ")),
+		nil,
+	)
+}
+
+// Query modes accepted by -query-mode
+const (
+	QueryModeSimple  = "simple"  // Words matched anywhere, CAPES' default
+	QueryModeExact   = "exact"   // The whole term as one quoted phrase
+	QueryModeBoolean = "boolean" // AND, OR, NOT, parentheses and quoted phrases
+)
+
+// QueryModes lists the accepted -query-mode values
+var QueryModes = []string{QueryModeSimple, QueryModeExact, QueryModeBoolean}
+
+// validateQueryMode validates the query mode and, for boolean queries, that
+// quotes and parentheses are balanced, since CAPES silently ignores a
+// malformed expression
+func validateQueryMode(params *SearchParams) error {
+	if params.QueryMode == "" {
+		params.QueryMode = QueryModeSimple
+	}
+	if !slices.Contains(QueryModes, params.QueryMode) {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid query mode: %s (must be one of: %s)", params.QueryMode, strings.Join(QueryModes, ", ")),
+			nil,
+		)
+	}
+	if params.QueryMode != QueryModeBoolean {
+		return nil
+	}
+	
+	depth, quoted := 0, false
+	for _, r := range params.SearchTerm {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == '(' && !quoted:
+			depth++
+		case r == ')' && !quoted:
+			depth--
+		}
+		if depth < 0 {
+			break
+		}
+	}
+	if depth != 0 || quoted {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid boolean query %q: unbalanced parentheses or quotes", params.SearchTerm),
+			nil,
+		)
+	}
+	
+	return nil
+}
+
+// validateColumns rejects export columns that are not in known
+func validateColumns(params *SearchParams, known []string) error {
+	if len(known) == 0 {
+		return nil
+	}
+	
+	for _, column := range params.Columns {
+		if !slices.Contains(known, column) {
+			return errors.NewConfigError(
+				fmt.Sprintf("unknown column: %s (valid columns: %s)", column, strings.Join(known, ", ")),
+				nil,
+			)
+		}
+	}
+	
+	return nil
+}
+
+// proxySchemes lists the proxy URL schemes Chromium accepts
+var proxySchemes = []string{"http", "https", "socks5", "socks5h"}
+
+// validateProxy checks that the proxy is a URL with a supported scheme and a
+// host, so a typo fails here instead of deep inside the browser launch
+func validateProxy(params *SearchParams) error {
+	params.Proxy = strings.TrimSpace(params.Proxy)
+	if params.Proxy == "" {
+		return nil // No proxy
+	}
+	
+	invalid := func(reason string) error {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid proxy %q: %s (use 'http://host:port', 'https://host:port', "+
+				"'socks5://host:port' or 'socks5h://host:port', optionally with 'user:pass@' before the host)",
+				params.Proxy, reason),
+			nil,
+		)
+	}
+	
+	proxyURL, err := url.Parse(params.Proxy)
+	if err != nil {
+		return invalid("not a valid URL")
+	}
+	if !slices.Contains(proxySchemes, strings.ToLower(proxyURL.Scheme)) {
+		return invalid(fmt.Sprintf("unsupported scheme %q", proxyURL.Scheme))
+	}
+	if proxyURL.Hostname() == "" {
+		return invalid("missing host")
+	}
+	
+	return nil
+}
+
+// delimiterNames maps the named CSV delimiters accepted by -delimiter
+var delimiterNames = map[string]rune{
+	"comma":     ',',
+	"semicolon": ';',
+	"tab":       '\t',
+}
+
+// validateDelimiter resolves Delimiter, a name from delimiterNames or a
+// single character, into CSVDelimiter; empty means a comma
+func validateDelimiter(params *SearchParams) error {
+	params.CSVDelimiter = ','
+	if params.Delimiter == "" {
+		return nil
+	}
+	
+	if delimiter, ok := delimiterNames[strings.ToLower(params.Delimiter)]; ok {
+		params.CSVDelimiter = delimiter
+		return nil
+	}
+	
+	delimiter, size := utf8.DecodeRuneInString(params.Delimiter)
+	if size != len(params.Delimiter) || delimiter == utf8.RuneError {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid delimiter: %q (must be 'comma', 'semicolon', 'tab' or a single character)", params.Delimiter),
+			nil,
+		)
+	}
+	// encoding/csv can't separate fields with quotes or line breaks
+	if delimiter == '"' || delimiter == '\r' || delimiter == '\n' {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid delimiter: %q can't separate CSV fields", params.Delimiter),
+			nil,
+		)
+	}
+	
+	params.CSVDelimiter = delimiter
+	return nil
+}
+
+// minWindowDimension is the smallest window width or height accepted; below
+// it CAPES switches to its mobile layout, which the selectors don't match
+const minWindowDimension = 400
+
+// validateWindowSize parses WindowSize ("1920x1080") into the viewport
+// dimensions; an empty size keeps the browser's own default
+func validateWindowSize(params *SearchParams) error {
+	params.ViewportWidth, params.ViewportHeight = 0, 0
+	if params.WindowSize == "" {
+		return nil
+	}
+	
+	width, height, err := parseWindowSize(params.WindowSize)
+	if err != nil {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid window size: %s (use WIDTHxHEIGHT, e.g. '1920x1080')", params.WindowSize),
+			err,
+		)
+	}
+	
+	params.ViewportWidth = width
+	params.ViewportHeight = height
+	return nil
+}
+
+// parseWindowSize splits "WIDTHxHEIGHT" into two positive dimensions
+func parseWindowSize(spec string) (int, int, error) {
+	w, h, ok := strings.Cut(strings.ToLower(spec), "x")
+	if !ok {
+		return 0, 0, fmt.Errorf("missing 'x' separator")
+	}
+	
+	width, err := strconv.Atoi(strings.TrimSpace(w))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid width: %w", err)
+	}
+	height, err := strconv.Atoi(strings.TrimSpace(h))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid height: %w", err)
+	}
+	if width < minWindowDimension || height < minWindowDimension {
+		return 0, 0, fmt.Errorf("width and height must be at least %d", minWindowDimension)
+	}
+	
+	return width, height, nil
+}
+
+// validateProfileDir checks that the browser profile, if it exists, is a
+// directory; a missing one is created at launch
+func validateProfileDir(params *SearchParams) error {
+	if params.ProfileDir == "" {
+		return nil
+	}
+	
+	info, err := os.Stat(params.ProfileDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.NewConfigError(
+			fmt.Sprintf("browser profile %q can't be read", params.ProfileDir),
+			err,
+		)
+	}
+	if !info.IsDir() {
+		return errors.NewConfigError(
+			fmt.Sprintf("browser profile %q is not a directory", params.ProfileDir),
+			nil,
+		)
+	}
+	
+	return nil
+}
+
+// validateChromePath checks that the configured browser executable exists
+func validateChromePath(params *SearchParams) error {
+	if params.ChromePath == "" {
+		return nil // Auto-managed browser
+	}
+	
+	info, err := os.Stat(params.ChromePath)
+	if err != nil {
+		return errors.NewConfigError(
+			fmt.Sprintf("browser executable %q not found", params.ChromePath),
+			err,
+		)
+	}
+	if info.IsDir() {
+		return errors.NewConfigError(
+			fmt.Sprintf("browser executable %q is a directory", params.ChromePath),
+			nil,
+		)
+	}
+	
+	return nil
+}
+
+// validateUserAgentFile checks that the user agent file can be read and
+// lists at least one user agent, ignoring blank lines and "#" comments
+func validateUserAgentFile(params *SearchParams) error {
+	if params.UserAgentFile == "" {
+		return nil // Built-in list
+	}
+	
+	data, err := os.ReadFile(params.UserAgentFile)
+	if err != nil {
+		return errors.NewConfigError(
+			fmt.Sprintf("cannot read user agent file %q", params.UserAgentFile),
+			err,
+		)
+	}
+	
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			return nil
+		}
+	}
+	return errors.NewConfigError(
+		fmt.Sprintf("user agent file %q has no user agents", params.UserAgentFile),
+		nil,
+	)
+}
+
 // validatePeerReview validates and normalizes the peer review parameter
 func validatePeerReview(params *SearchParams) error {
 	if params.PeerReviewed == "" {
@@ -136,16 +702,123 @@ func validateYears(params *SearchParams) error {
 	return nil
 }
 
+// PublicationTypes lists the publication types exactly as the CAPES portal
+// expects them in the type[] filter
+var PublicationTypes = []string{
+	"Artigo",
+	"Livro",
+	"Capítulo de livro",
+	"Revisão",
+	"Resenha",
+	"Editorial",
+	"Carta",
+	"Errata",
+	"Anais de congresso",
+	"Dissertação",
+	"Tese",
+	"Relatório",
+}
+
+// validatePublicationTypes rewrites each publication type to the spelling
+// CAPES expects, matching case and accents loosely. An empty list means any
+func validatePublicationTypes(params *SearchParams) error {
+	for i, pubType := range params.PublicationTypes {
+		canonical, known := canonicalPublicationType(pubType)
+		if !known {
+			return errors.NewConfigError(
+				fmt.Sprintf("invalid publication type: %s (must be one of: %s)", pubType, strings.Join(PublicationTypes, ", ")),
+				nil,
+			)
+		}
+		params.PublicationTypes[i] = canonical
+	}
+	return nil
+}
+
+// validateSubjectAreas trims the knowledge areas and rejects empty ones
+func validateSubjectAreas(params *SearchParams) error {
+	for i, area := range params.SubjectAreas {
+		area = strings.TrimSpace(area)
+		if area == "" {
+			return errors.NewConfigError(fmt.Sprintf("invalid knowledge area #%d: must not be empty", i+1), nil)
+		}
+		params.SubjectAreas[i] = area
+	}
+	return nil
+}
+
+// canonicalPublicationType returns the CAPES spelling of pubType and whether it is known
+func canonicalPublicationType(pubType string) (string, bool) {
+	key := foldName(pubType)
+	for _, canonical := range PublicationTypes {
+		if foldName(canonical) == key {
+			return canonical, true
+		}
+	}
+	return strings.TrimSpace(pubType), false
+}
+
+// canonicalLanguages lists language names exactly as the CAPES portal expects
+// them in the language[] filter
+var canonicalLanguages = []string{
+	"Português",
+	"Inglês",
+	"Espanhol",
+	"Francês",
+	"Alemão",
+	"Italiano",
+	"Russo",
+	"Chinês",
+	"Japonês",
+	"Holandês",
+	"Polonês",
+	"Coreano",
+	"Árabe",
+	"Turco",
+	"Catalão",
+	"Sueco",
+	"Norueguês",
+	"Dinamarquês",
+	"Tcheco",
+	"Húngaro",
+	"Grego",
+	"Romeno",
+	"Ucraniano",
+	"Croata",
+	"Latim",
+}
+
+// foldName reduces a language or type name to a lowercase, accent-free key
+func foldName(name string) string {
+	return textnorm.Normalize(name)
+}
+
+// canonicalLanguage returns the CAPES spelling of lang and whether it is known
+func canonicalLanguage(lang string) (string, bool) {
+	key := foldName(lang)
+	for _, canonical := range canonicalLanguages {
+		if foldName(canonical) == key {
+			return canonical, true
+		}
+	}
+	return strings.TrimSpace(lang), false
+}
+
 // normalizeLanguages ensures languages are properly formatted
-func normalizeLanguages(params *SearchParams) {
+// Known languages are rewritten to the exact casing and accents CAPES expects;
+// unknown ones are kept as typed and reported through log
+func normalizeLanguages(params *SearchParams, log logger.Logger) {
 	// Nothing to do if no languages
 	if len(params.Languages) == 0 {
 		return
 	}
 	
-	// Trim whitespace from each language
 	for i, lang := range params.Languages {
-		params.Languages[i] = strings.TrimSpace(lang)
+		canonical, known := canonicalLanguage(lang)
+		if !known && log != nil {
+			log.Warn("Unknown language '%s', it may not match any CAPES filter", canonical)
+		}
+		params.Languages[i] = canonical
 	}
 }
 
@@ -156,13 +829,9 @@ func validateExportParams(params *SearchParams) error {
 		return errors.NewConfigError("output file is required when export is enabled", nil)
 	}
 	
-	// Validate export format
-	if params.ExportFormat != "" && params.ExportFormat != "csv" {
-		return errors.NewConfigError(
-			fmt.Sprintf("unsupported export format: %s (only 'csv' is currently supported)",
-						params.ExportFormat),
-			nil,
-		)
+	// Validate export formats
+	if err := validateExportFormats(params); err != nil {
+		return errors.WithCode(err, errors.CodeInvalidFormat)
 	}
 	
 	// Validate max pages
@@ -173,5 +842,276 @@ func validateExportParams(params *SearchParams) error {
 		)
 	}
 	
+	// Open access only mode refines abstract extraction, it can't be used alone
+	if params.AbstractsOpenAccessOnly && !params.ExtractAbstracts {
+		return errors.NewConfigError("-abstracts-oa-only requires -abstracts", nil)
+	}
+	
+	// Validate fuzzy title deduplication distance
+	if params.TitleDedupDistance < 0 {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid title dedup distance: %d (must be 0 or positive)", params.TitleDedupDistance),
+			nil,
+		)
+	}
+	
+	// Validate polling interval
+	if params.PollInterval <= 0 {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid poll interval: %v (must be positive)", params.PollInterval),
+			nil,
+		)
+	}
+	
+	// Validate detail request spacing
+	if params.DetailDelay < 0 {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid detail delay: %v (must be 0 or positive)", params.DetailDelay),
+			nil,
+		)
+	}
+	
+	// Validate element lookup timeout
+	if params.ElementTimeout <= 0 {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid element timeout: %v (must be positive)", params.ElementTimeout),
+			nil,
+		)
+	}
+	
+	// Validate self-throttling settings
+	if params.ThrottleMaxDelay < 0 {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid throttle max delay: %v (must be 0 or positive)", params.ThrottleMaxDelay),
+			nil,
+		)
+	}
+	
+	// Validate navigation retry backoff
+	if params.RetryInitialDelay <= 0 {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid retry initial delay: %v (must be positive)", params.RetryInitialDelay),
+			nil,
+		)
+	}
+	if params.RetryMaxDelay < params.RetryInitialDelay {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid retry max delay: %v (must be at least the initial delay of %v)",
+				params.RetryMaxDelay, params.RetryInitialDelay),
+			nil,
+		)
+	}
+	
+	// Validate existing output file handling
+	switch params.OnExisting {
+	case "":
+		params.OnExisting = "overwrite"
+	case "overwrite", "fail", "backup", "timestamp":
+	default:
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid on-existing mode: %s (must be 'overwrite', 'fail', 'backup' or 'timestamp')", params.OnExisting),
+			nil,
+		)
+	}
+	
+	// In fail mode, refuse before the search runs rather than when the
+	// export is written, so no format is written when another one exists
+	if params.OnExisting == "fail" && !params.Append {
+		if err := validateOutputFree(params); err != nil {
+			return errors.WithCode(err, errors.CodeOutputExists)
+		}
+	}
+	
+	// Resolve the CSV delimiter
+	if err := validateDelimiter(params); err != nil {
+		return err
+	}
+	
+	// Validate author separator
+	if strings.TrimSpace(params.AuthorSeparator) == "" && params.AuthorSeparator != "" {
+		return errors.NewConfigError("invalid author separator: must contain a visible character", nil)
+	}
+	
+	// Validate minimum result count
+	if params.MinResults < 0 {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid min results: %d (must be 0 or positive)", params.MinResults),
+			nil,
+		)
+	}
+	
+	// Validate result limit
+	if params.MaxResults < 0 {
+		return errors.NewConfigError(
+			fmt.Sprintf("invalid max results: %d (must be 0 or positive)", params.MaxResults),
+			nil,
+		)
+	}
+	
+	// Only plain CSV files can be appended to
+	if params.Append {
+		for _, format := range params.ExportFormats {
+			if format != "csv" {
+				return errors.WithCode(errors.NewConfigError(
+					fmt.Sprintf("-append only works with CSV exports, not %s", format),
+					nil,
+				), errors.CodeConflictingFlags)
+			}
+		}
+		if params.Compress {
+			return errors.WithCode(errors.NewConfigError("-append can't be combined with -compress", nil), errors.CodeConflictingFlags)
+		}
+	}
+	
+	// Without detail pages there are no authors, abstracts or years to work with
+	if params.SkipDetails {
+		if err := validateNoDetailsParams(params); err != nil {
+			return errors.WithCode(err, errors.CodeConflictingFlags)
+		}
+	}
+	
+	// A streamed export is written while extracting, so everything that needs
+	// the complete result set first can't be combined with it
+	if params.StreamToDisk {
+		if err := validateStreamParams(params); err != nil {
+			return errors.WithCode(err, errors.CodeConflictingFlags)
+		}
+	}
+	
+	return nil
+}
+
+// validateExportFormats splits ExportFormat into ExportFormats, checking
+// every format and dropping repeats. Each format is written to its own file,
+// named after OutputFile with the format's extension
+func validateExportFormats(params *SearchParams) error {
+	params.ExportFormats = nil
+	seen := make(map[string]bool)
+	
+	for _, format := range strings.Split(params.ExportFormat, ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format == "" {
+			continue
+		}
+		
+		switch format {
+		case "csv", "bibtex", "xlsx", "markdown", "html", "ris", "ndjson":
+		default:
+			return errors.NewConfigError(
+				fmt.Sprintf("unsupported export format: %s (must be 'csv', 'xlsx', 'bibtex', 'markdown', 'html', 'ris' or 'ndjson')",
+							format),
+				nil,
+			)
+		}
+		
+		if !seen[format] {
+			seen[format] = true
+			params.ExportFormats = append(params.ExportFormats, format)
+		}
+	}
+	
+	if len(params.ExportFormats) == 0 {
+		params.ExportFormats = []string{"csv"}
+	}
+	return nil
+}
+
+// formatExtensions are the file extensions of the export formats not named
+// after theirs, as in result.ExportFormat.Extension
+var formatExtensions = map[string]string{
+	"bibtex":   "bib",
+	"markdown": "md",
+}
+
+// exportPaths returns the file each format of the export is written to, named
+// like result.ExportFilePath does: OutputFile with the format's extension,
+// plus ".gz" when compressing
+func exportPaths(params *SearchParams) []string {
+	base := strings.TrimSuffix(params.OutputFile, ".gz")
+	paths := make([]string, 0, len(params.ExportFormats))
+	for _, format := range params.ExportFormats {
+		ext, ok := formatExtensions[format]
+		if !ok {
+			ext = format
+		}
+		
+		path := base
+		if current := filepath.Ext(base); current == "" {
+			path = base + "." + ext
+		} else if current[1:] != ext {
+			path = strings.TrimSuffix(base, current) + "." + ext
+		}
+		if params.Compress {
+			path += ".gz"
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// validateOutputFree checks that none of the export files exists yet, for
+// the fail mode of -on-existing
+func validateOutputFree(params *SearchParams) error {
+	for _, path := range exportPaths(params) {
+		if _, err := os.Stat(path); err == nil {
+			return errors.NewConfigError(
+				fmt.Sprintf("output file %s already exists (use -on-existing overwrite, backup or timestamp)", path),
+				nil,
+			)
+		}
+	}
+	return nil
+}
+
+// validateNoDetailsParams rejects the options that need data only found on
+// the detail pages skipped by -no-details
+func validateNoDetailsParams(params *SearchParams) error {
+	conflicts := []struct {
+		set  bool
+		flag string
+	}{
+		{params.ExtractAbstracts, "-abstracts"},
+		{params.NormalizeAuthors, "-normalize-authors"},
+		{len(params.AuthorFilter) > 0, "-author"},
+		{params.TitleDedupDistance > 0, "-title-dedup-distance"},
+		{params.SinceSpec != "", "-since"},
+	}
+	
+	for _, conflict := range conflicts {
+		if conflict.set {
+			return errors.NewConfigError(
+				fmt.Sprintf("-no-details can't be combined with %s, which needs data from the detail pages", conflict.flag),
+				nil,
+			)
+		}
+	}
+	
+	return nil
+}
+
+// validateStreamParams rejects the options that need every result in memory
+// before the export is written
+func validateStreamParams(params *SearchParams) error {
+	conflicts := []struct {
+		set  bool
+		flag string
+	}{
+		{params.Resume, "-resume"},
+		{params.TitleDedupDistance > 0, "-title-dedup-distance"},
+		{len(params.AuthorFilter) > 0, "-author"},
+		{params.MinResults > 0, "-min-results"},
+		{params.NoExportOnEmpty, "-no-export-on-empty"},
+		{params.SinceSpec != "", "-since"},
+	}
+	
+	for _, conflict := range conflicts {
+		if conflict.set {
+			return errors.NewConfigError(
+				fmt.Sprintf("-stream can't be combined with %s, which needs all results before exporting", conflict.flag),
+				nil,
+			)
+		}
+	}
+	
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexandreffaria/reviu/internal/errors"
+	"github.com/alexandreffaria/reviu/internal/logger"
+)
+
+func TestExportPaths(t *testing.T) {
+	tests := []struct {
+		output   string
+		formats  []string
+		compress bool
+		want     []string
+	}{
+		{"out", []string{"csv"}, false, []string{"out.csv"}},
+		{"out.csv", []string{"csv", "bibtex", "markdown"}, false, []string{"out.csv", "out.bib", "out.md"}},
+		{"dir/out.csv.gz", []string{"csv", "ris"}, true, []string{"dir/out.csv.gz", "dir/out.ris.gz"}},
+	}
+
+	for _, tt := range tests {
+		params := &SearchParams{OutputFile: tt.output, ExportFormats: tt.formats, Compress: tt.compress}
+		if got := exportPaths(params); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("exportPaths(%q, %v) = %v, want %v", tt.output, tt.formats, got, tt.want)
+		}
+	}
+}
+
+func TestValidateOutputFreeChecksEveryFormat(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "results.csv")
+	params := &SearchParams{OutputFile: output, ExportFormats: []string{"csv", "bibtex"}}
+
+	if err := validateOutputFree(params); err != nil {
+		t.Fatalf("validateOutputFree() = %v with no files, want nil", err)
+	}
+
+	// Only the second format's file exists, which used to be found after
+	// the first one had already been written
+	if err := os.WriteFile(filepath.Join(dir, "results.bib"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	err := validateOutputFree(params)
+	if !errors.IsErrorType(err, errors.Configuration) {
+		t.Fatalf("validateOutputFree() = %v, want a configuration error", err)
+	}
+	if _, statErr := os.Stat(output); !os.IsNotExist(statErr) {
+		t.Error("validation created the CSV file")
+	}
+}
+
+func TestValidateExportParamsFailMode(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "results.csv")
+	if err := os.WriteFile(filepath.Join(dir, "results.ris"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []string{"overwrite", "backup", "timestamp", "fail"} {
+		params := NewSearchParams()
+		params.OutputFile = output
+		params.ExportResults = true
+		params.ExportFormat = "csv,ris"
+		params.PollInterval = time.Second
+		params.OnExisting = mode
+
+		err := validateExportParams(params)
+		if mode != "fail" {
+			if err != nil {
+				t.Errorf("validateExportParams(%s) = %v, want nil", mode, err)
+			}
+			continue
+		}
+		if errors.Code(err) != errors.CodeOutputExists {
+			t.Errorf("validateExportParams(fail) = %v, want code %s", err, errors.CodeOutputExists)
+		}
+	}
+}
+
+func TestValidatePagesWithoutExport(t *testing.T) {
+	params := NewSearchParams()
+	params.SearchTerm = "soil"
+	params.URLListFile = filepath.Join(t.TempDir(), "urls.txt")
+	params.Pages = -1
+
+	err := (&DefaultValidator{}).ValidateSearchParams(params)
+	if !errors.IsErrorType(err, errors.Configuration) {
+		t.Errorf("ValidateSearchParams() = %v, want a configuration error for -pages -1", err)
+	}
+}
+
+func TestCanonicalLanguage(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		known bool
+	}{
+		{"Português", "Português", true},
+		{"português", "Português", true},
+		{"portugues", "Português", true},
+		{"  INGLES ", "Inglês", true},
+		{"espanhol", "Espanhol", true},
+		{"arabe", "Árabe", true},
+		{"Klingon", "Klingon", false},
+		{" élfico ", "élfico", false},
+	}
+
+	for _, tt := range tests {
+		got, known := canonicalLanguage(tt.input)
+		if got != tt.want || known != tt.known {
+			t.Errorf("canonicalLanguage(%q) = %q, %v; want %q, %v", tt.input, got, known, tt.want, tt.known)
+		}
+	}
+}
+
+func TestNormalizeLanguagesWarnsOnUnknown(t *testing.T) {
+	var logged strings.Builder
+	params := &SearchParams{Languages: []string{"portugues", "Klingon"}}
+	normalizeLanguages(params, logger.NewLogger(logger.WithWriter(&logged)))
+
+	if want := []string{"Português", "Klingon"}; !reflect.DeepEqual(params.Languages, want) {
+		t.Errorf("Languages = %v, want %v", params.Languages, want)
+	}
+	if !strings.Contains(logged.String(), "Klingon") || strings.Contains(logged.String(), "portugues") {
+		t.Errorf("log = %q, want a warning for the unknown language only", logged.String())
+	}
+}
+
+func TestParsePageRange(t *testing.T) {
+	tests := []struct {
+		spec       string
+		start, end int
+		wantErr    bool
+	}{
+		{"5-10", 5, 10, false},
+		{" 2 - 3 ", 2, 3, false},
+		{"7", 7, 7, false},
+		{"4-4", 4, 4, false},
+		{"10-5", 0, 0, true},
+		{"0-3", 0, 0, true},
+		{"-3", 0, 0, true},
+		{"2-", 0, 0, true},
+		{"a-b", 0, 0, true},
+		{"", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		start, end, err := parsePageRange(tt.spec)
+		if (err != nil) != tt.wantErr || start != tt.start || end != tt.end {
+			t.Errorf("parsePageRange(%q) = %d, %d, %v; want %d, %d, error %v",
+				tt.spec, start, end, err, tt.start, tt.end, tt.wantErr)
+		}
+	}
+}
+
+func TestValidatePageRange(t *testing.T) {
+	params := NewSearchParams()
+	params.PageRange = "5-10"
+	if err := validatePageRange(params); err != nil || params.PageRangeStart != 5 || params.PageRangeEnd != 10 {
+		t.Errorf("validatePageRange(5-10) = %v with pages %d to %d, want 5 to 10",
+			err, params.PageRangeStart, params.PageRangeEnd)
+	}
+
+	params.PageRange = "10-5"
+	if err := validatePageRange(params); !errors.IsErrorType(err, errors.Configuration) {
+		t.Errorf("validatePageRange(10-5) = %v, want a configuration error", err)
+	}
+	if params.PageRangeStart != 0 || params.PageRangeEnd != 0 {
+		t.Errorf("invalid range left pages %d to %d, want them cleared", params.PageRangeStart, params.PageRangeEnd)
+	}
+}
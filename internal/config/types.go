@@ -3,6 +3,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -12,30 +13,110 @@ type SearchParams struct {
 	SearchTerm string
 
 	// Optional parameters
+	Source         string // CAPES source facet, "" searches all sources
 	AccessType     string // "sim", "nao", or "" (any)
-	PublicationType string
+	PublicationTypes []string // Types to filter on, any of them matches
 	YearMin        int
 	YearMax        int
 	PeerReviewed   string // "sim", "nao", or "" (any)
 	Languages      []string
+	SubjectAreas   []string // Knowledge areas ("área de conhecimento") to filter on
+	SortOrder      string // Result ordering, "" keeps the portal's own (see SortOrders)
+	QueryMode      string // How the search term is sent: "simple", "exact" or "boolean"
+
+	// ConfigFile is the YAML or JSON file the parameters were loaded from
+	ConfigFile string
+	configErr  error // Error loading ConfigFile, returned by the validator
 
 	// Export configuration
 	OutputFile      string // Path to output file for search results
 	ExportResults   bool   // Whether to export results (default: true if OutputFile is set)
-	ExportFormat    string // Format to use for export (default: "csv")
+	ExportFormat    string // Format to use for export, or a comma-separated list of formats (default: "csv")
 	MaxPages        int    // Maximum number of pages to process (0 = all)
+	PageRange       string // Pages to process as "A-B" or "A", takes precedence over MaxPages
+	MaxResults      int    // Stop after the first N results have been fully extracted (0 = no limit)
+	URLListFile     string // Write the page URLs of the search to this file instead of extracting
+	Pages           int    // Number of pages for the URL list (0 = read the total from CAPES)
 	IncludeHeaders  bool   // Whether to include headers in CSV export (default: true)
+	JSONSummary     bool   // Print a machine-readable run summary to stdout at the end
+	Compress        bool   // Gzip the export and append ".gz" to its file name
+	WriteBOM        bool   // Start CSV exports with a UTF-8 byte order mark for Excel
+	Delimiter       string // CSV field separator: comma, semicolon, tab or a single character
+	QuoteAll        bool   // Quote every CSV field, not only those that need it
+	Columns         []string // Columns of CSV/XLSX exports, in order (empty = all default columns)
+	OnExisting      string // What to do when the output file exists: overwrite, fail, backup or timestamp
+	Append          bool   // Add the rows to an existing CSV export instead of replacing it
+	NoExportOnEmpty bool   // Leave the output file untouched when the run finds no results
+	MinResults      int    // Leave the output file untouched below this many results (0 = off)
+	Resume          bool   // Continue an interrupted export from its checkpoint file
+	StreamToDisk    bool   // Write each page's results as it is extracted instead of keeping them in memory
+	
+	// Detail extraction options
+	ExtractAbstracts        bool // Fetch abstracts from the details page
+	AbstractsOpenAccessOnly bool // Only fetch abstracts for open access results
+	SkipDetails             bool // Don't visit detail pages; only title and link are exported
+	NormalizeAuthors        bool // Add author names in "Surname, Given" form
+	AuthorSeparator         string // Joins multiple authors in the "Autor" column
+	
+	// Post-processing options
+	TitleDedupDistance int // Maximum edit distance for fuzzy title deduplication (0 = off)
+	AuthorFilter       []string // Keep only results listing one of these authors (case- and accent-insensitive)
+	SinceSpec          string   // Keep only results published from this date on ("2024", "2024-03-01") or since the last run ("last")
 	
 	// Browser options
 	RodOptions      string        // Rod options string
+	Headless        bool          // Run the browser without a visible window
 	StealthMode     bool          // Enable stealth mode to avoid bot detection
 	RandomUserAgent bool          // Use random user agent
+	UserAgentFile   string        // User agents to choose from, one per line (empty = built-in list)
+	AbortOnBlock    bool          // Stop when CAPES serves a block or captcha page
 	SlowMotion      time.Duration // Add delay between browser operations
 	Proxy           string        // Use proxy for requests
+	ChromePath      string        // Chrome/Chromium executable (empty = auto-managed)
+	ProfileDir      string        // Browser profile kept between runs (empty = fresh profile every launch)
+	WindowSize      string        // Browser window and viewport size ("1920x1080")
 	PageDelay       time.Duration // Delay between page requests to avoid being blocked
+	DetailDelay     time.Duration // Average delay between detail page requests (randomized ± 30%)
+	PollInterval    time.Duration // How often polling-based waits re-check the page
+	ElementTimeout  time.Duration // Maximum time for a single element lookup
+	RetryOnEmptyPage bool         // Reload pages that unexpectedly return no results
+	ThrottleFactor   float64       // Page load slowdown that triggers extra delay (<= 1 disables)
+	ThrottleMaxDelay time.Duration // Maximum extra delay added by self-throttling
+	RetryInitialDelay time.Duration // Wait before the first retry of a failed page load
+	RetryMaxDelay     time.Duration // Cap on the exponentially growing wait between retries
+	PauseFile        string        // Pause between pages while this file exists
+	KillOrphans      bool          // Kill browser processes leaked by earlier runs on startup
+	DeadlineSpec     string        // Stop at the next page boundary after this time ("2h", "06:00", RFC 3339)
+
+	// Interface options
+	Locale string // Language of CLI messages, the search report and the summary CSV ("pt" or "en")
+	Quiet  bool   // Hide the export progress bar, leaving only the logs
+	LogFormat string // Format of log lines ("text" or "json")
+	LogLevel  string // Minimum level of log lines ("debug", "info", "warn" or "error")
+	LogFile   string // Also append the logs to this file ("" = console only)
+	ErrorJSON bool   // Print a failure as a JSON object {type, code, message} on stderr
+	ViewTime  time.Duration // How long view mode keeps the browser open (0 = until the window is closed)
+
+	// Tooling options
+	Preflight     bool // Check CAPES reachability and selectors before extracting
+	PreflightOnly bool // Run the preflight checks and exit
+	ScreenshotOnEmpty bool // Save a screenshot of results pages that return no links
+	DryRun            bool // Print the search report and URL without launching the browser
+	CountOnly         bool // Print the result and page counts of the search and exit
+	ListFlagsJSON bool // Print all flags as JSON and exit
+	ShowVersion   bool // Print the build version, commit and date and exit
 
 	// Computed parameters (populated during validation)
 	EffectiveYearMax int // Calculated max year value
+	Deadline         time.Time // Absolute time parsed from DeadlineSpec (zero = no deadline)
+	ExportFormats    []string  // Distinct formats parsed from ExportFormat, in the order given
+	Since            time.Time // Date parsed from SinceSpec (zero = no date filter)
+	SinceLastRun     bool      // SinceSpec is "last": the date comes from the run record of the export
+	PageRangeStart   int       // First page parsed from PageRange (0 = no range)
+	PageRangeEnd     int       // Last page parsed from PageRange (0 = no range)
+	ViewportWidth    int       // Window width parsed from WindowSize
+	ViewportHeight   int       // Window height parsed from WindowSize
+	CSVDelimiter     rune      // Field separator resolved from Delimiter
 	CurrentYear      int // Current year (for relative calculations)
 	Valid            bool // Indicates if parameters have been validated
 }
@@ -64,8 +145,19 @@ func NewSearchParams() *SearchParams {
 		CurrentYear:      time.Now().Year(),
 		StealthMode:      true,
 		RandomUserAgent:  true,
+		AbortOnBlock:     true,
+		ElementTimeout:   5 * time.Second,
+		WindowSize:       "1920x1080",
+		Delimiter:        "comma",
+		QueryMode:        QueryModeSimple,
+		RetryInitialDelay: time.Second,
+		ViewTime:          30 * time.Second,
+		RetryMaxDelay:     30 * time.Second,
 		SlowMotion:       200 * time.Millisecond,
 		PageDelay:        2 * time.Second,
+		RetryOnEmptyPage: true,
+		ThrottleFactor:   2.0,
+		ThrottleMaxDelay: 60 * time.Second,
 		IncludeHeaders:   true,
 	}
 }
@@ -103,8 +195,8 @@ func (p *SearchParams) String() string {
 	}
 
 	pubType := "qualquer"
-	if p.PublicationType != "" {
-		pubType = p.PublicationType
+	if len(p.PublicationTypes) > 0 {
+		pubType = strings.Join(p.PublicationTypes, ", ")
 	}
 
 	languages := "qualquer"
@@ -137,6 +229,10 @@ func (p *SearchParams) String() string {
 			result += ", MaxPages: all"
 		}
 		
+		if p.MaxResults > 0 {
+			result += ", MaxResults: " + fmt.Sprintf("%d", p.MaxResults)
+		}
+		
 		// Add page delay info
 		if p.PageDelay > 0 {
 			result += ", PageDelay: " + p.PageDelay.String()
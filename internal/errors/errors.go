@@ -17,11 +17,85 @@ const (
 	Browser
 	UserInput
 	External
+	EmptyResult
 )
 
+// String returns the lowercase name of the type, as used in JSON output
+func (t ErrorType) String() string {
+	switch t {
+	case Configuration:
+		return "configuration"
+	case Network:
+		return "network"
+	case Browser:
+		return "browser"
+	case UserInput:
+		return "user_input"
+	case External:
+		return "external"
+	case EmptyResult:
+		return "empty_result"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrorCode identifies a specific failure, so scripts can tell failures of
+// the same type apart. Codes are stable: once released, they aren't renamed
+type ErrorCode string
+
+const (
+	// Codes used when no specific code was set, one per error type
+	CodeUnknown       ErrorCode = "UNKNOWN"
+	CodeConfigInvalid ErrorCode = "CONFIG_INVALID"
+	CodeNetworkError  ErrorCode = "NETWORK_ERROR"
+	CodeBrowserError  ErrorCode = "BROWSER_ERROR"
+	CodeInputInvalid  ErrorCode = "INPUT_INVALID"
+	CodeExternalError ErrorCode = "EXTERNAL_ERROR"
+	CodeEmptyResult   ErrorCode = "EMPTY_RESULT"
+
+	// Specific configuration failures
+	CodeInvalidYear      ErrorCode = "CONFIG_INVALID_YEAR"
+	CodeInvalidPageRange ErrorCode = "CONFIG_INVALID_PAGE_RANGE"
+	CodeInvalidDeadline  ErrorCode = "CONFIG_INVALID_DEADLINE"
+	CodeInvalidSince     ErrorCode = "CONFIG_INVALID_SINCE"
+	CodeInvalidProxy     ErrorCode = "CONFIG_INVALID_PROXY"
+	CodeInvalidFormat    ErrorCode = "CONFIG_INVALID_FORMAT"
+	CodeInvalidColumns   ErrorCode = "CONFIG_INVALID_COLUMNS"
+	CodeConflictingFlags ErrorCode = "CONFIG_CONFLICTING_FLAGS"
+	CodeOutputExists     ErrorCode = "CONFIG_OUTPUT_EXISTS"
+	CodeBrowserNotFound  ErrorCode = "CONFIG_BROWSER_NOT_FOUND"
+	CodeProfileInUse     ErrorCode = "CONFIG_PROFILE_IN_USE"
+
+	// Specific network failures
+	CodeUnreachable ErrorCode = "NETWORK_UNREACHABLE"
+	CodeBlocked     ErrorCode = "NETWORK_BLOCKED"
+)
+
+// defaultCode returns the code of errors of type t without a specific code
+func (t ErrorType) defaultCode() ErrorCode {
+	switch t {
+	case Configuration:
+		return CodeConfigInvalid
+	case Network:
+		return CodeNetworkError
+	case Browser:
+		return CodeBrowserError
+	case UserInput:
+		return CodeInputInvalid
+	case External:
+		return CodeExternalError
+	case EmptyResult:
+		return CodeEmptyResult
+	default:
+		return CodeUnknown
+	}
+}
+
 // AppError represents an application-specific error with context
 type AppError struct {
 	Type    ErrorType
+	Code    ErrorCode // Specific failure; empty uses the default code of Type
 	Message string
 	Err     error
 }
@@ -82,6 +156,42 @@ func NewExternalError(message string, err error) error {
 	return NewError(External, message, err)
 }
 
+// Empty result errors, for runs that yielded too few results to export
+func NewEmptyResultError(message string, err error) error {
+	return NewError(EmptyResult, message, err)
+}
+
+// WithCode sets the specific code of err, which must be an *AppError as
+// returned by the constructors above; other errors are returned unchanged
+func WithCode(err error, code ErrorCode) error {
+	if appErr, ok := err.(*AppError); ok {
+		appErr.Code = code
+	}
+	return err
+}
+
+// Code returns the most specific code in the chain of err: the first one set
+// explicitly, else the default code of the outermost AppError's type
+func Code(err error) ErrorCode {
+	code := CodeUnknown
+	outermost := true
+	for err != nil {
+		var appErr *AppError
+		if !errors.As(err, &appErr) {
+			break
+		}
+		if appErr.Code != "" {
+			return appErr.Code
+		}
+		if outermost {
+			code = appErr.Type.defaultCode()
+			outermost = false
+		}
+		err = appErr.Err
+	}
+	return code
+}
+
 // IsErrorType checks if an error is of a specific type
 func IsErrorType(err error, errorType ErrorType) bool {
 	var appErr *AppError
@@ -89,4 +199,4 @@ func IsErrorType(err error, errorType ErrorType) bool {
 		return false
 	}
 	return errors.As(err, &appErr) && appErr.Type == errorType
-}
\ No newline at end of file
+}
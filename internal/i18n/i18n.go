@@ -0,0 +1,72 @@
+// Package i18n provides the message catalog for user-facing text
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Locale identifies the language used for user-facing messages
+type Locale string
+
+const (
+	LocalePT Locale = "pt"
+	LocaleEN Locale = "en"
+)
+
+// DefaultLocale is used when no locale is configured
+const DefaultLocale = LocalePT
+
+// localeEnvVars are checked in order for the system language, following the
+// POSIX precedence
+var localeEnvVars = []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+
+// Locales returns the locales with a message catalog, the default first
+func Locales() []Locale {
+	return []Locale{LocalePT, LocaleEN}
+}
+
+// IsSupported reports whether a message catalog exists for locale
+func IsSupported(locale string) bool {
+	_, ok := messages[Locale(locale)]
+	return ok
+}
+
+// Message returns the message for key in locale, formatted with args
+// Missing translations fall back to the default locale, then to the key itself
+func Message(locale Locale, key string, args ...interface{}) string {
+	text, ok := messages[locale][key]
+	if !ok {
+		text, ok = messages[DefaultLocale][key]
+	}
+	if !ok {
+		text = key
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// FromEnv returns the locale of the system language ("en_US.UTF-8" is
+// "en"), or false when it is unset or has no catalog
+func FromEnv() (Locale, bool) {
+	for _, name := range localeEnvVars {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+
+		// The first variable set decides, even when its language isn't supported
+		language, _, _ := strings.Cut(value, "_")
+		language, _, _ = strings.Cut(language, ".")
+		language = strings.ToLower(language)
+		if IsSupported(language) {
+			return Locale(language), true
+		}
+		return "", false
+	}
+	return "", false
+}
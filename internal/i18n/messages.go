@@ -0,0 +1,354 @@
+package i18n
+
+// messages is the catalog of user-facing messages keyed by locale and message ID
+// Report labels keep their trailing padding so values stay aligned
+var messages = map[Locale]map[string]string{
+	LocalePT: {
+		"prompt.searchTerm":               "TERMOS DE BUSCA",
+		"prompt.searchTermHint":           "texto livre (obrigatório)",
+		"prompt.required":                 "Campo obrigatório. Por favor, preencha.",
+		"report.title":                    " RELATÓRIO DA BUSCA",
+		"report.searchTerm":               "Termos de busca:   ",
+		"report.access":                   "Acesso aberto:     ",
+		"report.pubType":                  "Tipo de publicação: ",
+		"report.years":                    "Anos de publicação: ",
+		"report.yearRange":                "%s até %s",
+		"report.peerReview":               "Revisão por pares:  ",
+		"report.languages":                "Idiomas:            ",
+		"report.areas":                    "Áreas:              ",
+		"report.source":                   "Base de dados:      ",
+		"report.sort":                     "Ordenação:          ",
+		"report.sortDefault":              "padrão do portal",
+		"report.any":                      "qualquer",
+		"report.all":                      "todas",
+		"report.unspecified":              "não especificado",
+		"report.exportEnabled":            "Exportação de resultados: Habilitada",
+		"report.outputFile":               "Arquivo de saída: ",
+		"report.format":                   "Formato: ",
+		"report.maxPages":                 "Máximo de páginas: ",
+		"report.abstracts":                "Resumos: ",
+		"report.abstractsOAOnly":          "apenas acesso aberto",
+		"report.yes":                      "sim",
+		"report.maxResults":               "Máximo de resultados: ",
+		"report.since":                    "Publicados desde: ",
+		"report.sinceLastRun":             "a última execução",
+		"report.details":                  "Páginas de detalhes: ",
+		"report.noDetails":                "não visitadas (apenas título e link)",
+		"report.includeHeaders":           "Incluir cabeçalhos: ",
+		"report.pageDelay":                "Delay entre páginas: ",
+		"report.detailDelay":              "Delay entre detalhes: ",
+		"search.url":                      "URL da busca:",
+		"check.failed":                    "FALHA",
+		"preflight.title":                 "Verificação prévia:",
+		"preflight.passed":                "Verificação prévia concluída sem problemas.",
+		"export.progress":                 "%s %3d%% página %d de %d, %d resultados, faltam ~%v\r",
+		"export.completed":                "Exportação concluída:",
+		"export.pages":                    "- Páginas processadas: %d",
+		"export.results":                  "- Resultados exportados: %d",
+		"export.file":                     "- Arquivo salvo em: %s (%s)",
+		"export.duration":                 "- Tempo total: %s",
+		"export.throughput":               "- Ritmo: %.1f resultados por minuto",
+		"export.errors":                   "- Erros: %d (veja os avisos no log)",
+		"export.starting":                 "Iniciando exportação de resultados para: %s",
+		"export.mayTakeMinutes":           "Este processo pode demorar alguns minutos dependendo do número de resultados...",
+		"export.noResults":                "A busca foi concluída, mas não encontrou resultados (0 resultados).",
+		"export.interrupted":              "Extração interrompida: %d resultados coletados até a interrupção foram salvos em: %s",
+		"export.resumeHint":               "Execute o mesmo comando com -resume para continuar de onde parou.",
+		"export.openHint":                 "Você pode abrir o arquivo CSV em um editor de planilhas como Excel ou LibreOffice Calc.",
+		"urlList.written":                 "Lista com %d URLs de páginas gravada em: %s",
+		"dryRun.done":                     "Simulação (-dry-run): o navegador não foi aberto.",
+		"count.result":                    "%d resultados (%d páginas)",
+		"view.opening":                    "Abrindo navegador com a URL de busca...",
+		"view.success":                    "Busca realizada com sucesso.",
+		"view.keepOpen":                   "Mantendo navegador aberto por %s para visualização dos resultados.",
+		"view.keepOpenUntilClosed":        "Mantendo navegador aberto até que você feche a janela (ou pressione Ctrl+C).",
+		"error.config":                    "Erro de configuração: %v",
+		"error.input":                     "Erro de entrada: %v",
+		"error.browser":                   "Erro do navegador: %v",
+		"error.application":               "Erro da aplicação: %v",
+		"error.emptyResults":              "Exportação não realizada: %v",
+		"error.network":                   "Erro de rede, não foi possível acessar a CAPES (verifique sua conexão ou proxy): %v",
+		"error.unexpected":                "Erro inesperado: %v",
+		"usage.title":                     "Uso: capes-search [flags]",
+		"usage.search":                    "Flags de busca:",
+		"usage.export":                    "Flags de exportação:",
+		"usage.interface":                 "Flags de interface:",
+		"usage.diagnostics":               "Flags de diagnóstico:",
+		"usage.antiBlock":                 "Flags de proteção anti-bloqueio:",
+		"usage.examples":                  "Exemplos:",
+		"usage.flagsOf":                   "Uso de %s:",
+		"usage.flag.search":               "Termo de busca (ex: 'inteligência artificial')",
+		"usage.flag.oa":                   "Acesso aberto: 'sim', 'nao' ou omitir para qualquer",
+		"usage.flag.t":                    "Tipo de publicação (ex: 'Artigo'); repita para vários tipos",
+		"usage.flag.pymin":                "Ano mínimo de publicação (ex: 2010)",
+		"usage.flag.pymax":                "Ano máximo de publicação (ex: 2023)",
+		"usage.flag.pr":                   "Revisão por pares: 'sim', 'nao' ou omitir para qualquer",
+		"usage.flag.lang":                 "Idioma; repita para vários (ex: -lang Português -lang Inglês) ou separe por '/'",
+		"usage.flag.area":                 "Área de conhecimento; repita para várias (ex: -area 'Ciências da Saúde')",
+		"usage.flag.source":               "Base de dados (parâmetro 'source' da CAPES; vazio = todas)",
+		"usage.flag.query-mode":           "Como o termo é enviado: 'simple' (padrão, palavras soltas), 'exact' ou 'boolean':\n            -search \"saúde mental\" -query-mode exact        busca a frase exata\n            -search \"(ansiedade OR depressão) AND adolescentes NOT adultos\" -query-mode boolean",
+		"usage.flag.sort":                 "Ordenação: 'relevance', 'date_desc' (mais recentes), 'date_asc' ou 'title' (padrão: a do portal)",
+		"usage.flag.config":               "Arquivo YAML ou JSON com os parâmetros da busca (ex: 'revisoes/vacinas.yaml')",
+		"usage.flag.output":               "Arquivo para salvar os resultados (ex: 'resultados.csv')",
+		"usage.flag.format":               "Formato de exportação: 'csv', 'xlsx' (Excel), 'bibtex', 'markdown', 'html', 'ris' (EndNote) ou 'ndjson' (um JSON por linha);\n              separe vários por vírgula para exportar todos de uma vez (ex: 'csv,bibtex')",
+		"usage.flag.max-pages":            "Número máximo de páginas a processar (0 = todas)",
+		"usage.flag.page-range":           "Intervalo de páginas a processar (ex: '5-10'); tem prioridade sobre -max-pages",
+		"usage.flag.no-headers":           "Não incluir cabeçalhos no arquivo CSV",
+		"usage.flag.max-results":          "Extrair apenas os N primeiros resultados (0 = todos; combina com -max-pages)",
+		"usage.flag.abstracts":            "Extrair o resumo de cada resultado (coluna 'Resumo')",
+		"usage.flag.abstracts-oa-only":    "Com -abstracts, extrair resumos apenas de itens de acesso aberto",
+		"usage.flag.no-details":           "Não visitar a página de cada publicação: bem mais rápido, mas exporta só título e link",
+		"usage.flag.normalize-authors":    "Preencher a coluna 'Autor (normalizado)' no formato 'Sobrenome, Nome'",
+		"usage.flag.author-separator":     "Separador entre autores na coluna 'Autor' (padrão: '; ')",
+		"usage.flag.title-dedup-distance": "Remover títulos quase idênticos do mesmo ano (ex: 3; 0 = desativado)",
+		"usage.flag.author":               "Manter apenas resultados deste autor, sem diferenciar maiúsculas e acentos; repita para vários",
+		"usage.flag.since":                "Atualização incremental: só resultados publicados desde a data (ex: '2024', '2024-03-01') ou\n              desde a última execução ('last'), ignorando os já exportados ('<saída>.lastrun')",
+		"usage.flag.dump-url-list":        "Gravar as URLs de cada página da busca em um arquivo, sem extrair resultados",
+		"usage.flag.pages":                "Número de páginas para -dump-url-list (0 = consultar o total na CAPES)",
+		"usage.flag.on-existing":          "Se o arquivo de saída existir: overwrite (padrão), fail, backup ou timestamp",
+		"usage.flag.append":               "Acrescentar os resultados a um CSV existente (mesmas colunas), sem repetir publicações",
+		"usage.flag.columns":              "Colunas do CSV/XLSX e sua ordem (ex: 'titulo,autor,ano,doi,link'); valores:\n              titulo, autor, autor_normalizado, ano, periodico, link, doi, issn, editora, acesso_aberto,\n              resumo, citacao, palavras_chave, fontes",
+		"usage.flag.bom":                  "Iniciar o CSV com o BOM UTF-8 para o Excel exibir acentos (padrão: true no Windows)",
+		"usage.flag.delimiter":            "Separador do CSV: 'comma' (padrão), 'semicolon', 'tab' ou um único caractere",
+		"usage.flag.quote-all":            "Colocar todos os campos do CSV entre aspas",
+		"usage.flag.compress":             "Compactar o arquivo exportado com gzip (acrescenta '.gz' ao nome)",
+		"usage.flag.no-export-on-empty":   "Não sobrescrever o arquivo de saída quando a busca vier vazia (código de saída 4)",
+		"usage.flag.min-results":          "Não sobrescrever o arquivo de saída abaixo de N resultados (código de saída 4)",
+		"usage.flag.stream":               "Gravar os resultados de cada página assim que extraídos, economizando memória em buscas grandes",
+		"usage.flag.resume":               "Continuar uma exportação interrompida a partir do checkpoint ('<saída>.checkpoint')",
+		"usage.flag.json-summary":         "Imprimir um resumo JSON da execução no stdout (logs vão para o stderr)",
+		"usage.flag.lang-ui":              "Idioma das mensagens, do relatório e do resumo CSV: 'pt' (padrão) ou 'en'; sem a flag, segue a variável LANG",
+		"usage.flag.quiet":                "Não exibir a barra de progresso da exportação (útil em scripts)",
+		"usage.flag.view-time":            "Sem -output, tempo que o navegador fica aberto (padrão: 30s; 0 = até fechar a janela)",
+		"usage.flag.log-format":           "Formato dos logs: 'text' (padrão) ou 'json' (um objeto por linha)",
+		"usage.flag.log-level":            "Nível mínimo dos logs: 'debug', 'info' (padrão), 'warn' ou 'error'",
+		"usage.flag.log-file":             "Também gravar os logs neste arquivo, sem deixar de exibi-los no terminal",
+		"usage.flag.error-json":           "Em caso de falha, imprimir no stderr um JSON {type, code, message} em vez da mensagem (para scripts)",
+		"usage.flag.preflight":            "Verificar a CAPES e os seletores antes de extrair",
+		"usage.flag.preflight-only":       "Apenas executar a verificação prévia e sair",
+		"usage.flag.dry-run":              "Exibir o relatório e a URL da busca sem abrir o navegador",
+		"usage.flag.count":                "Apenas exibir quantos resultados e páginas a busca retorna, sem extrair",
+		"usage.flag.screenshot-on-empty":  "Salvar captura de tela das páginas sem resultados em debug/",
+		"usage.flag.version":              "Exibir a versão, o commit e a data de compilação e sair",
+		"usage.flag.delay":                "Espera entre páginas para evitar bloqueio (ex: '5s', '10s')",
+		"usage.flag.detail-delay":         "Espera média entre páginas de detalhes, variando ±30% (ex: '1s'; padrão: 0)",
+		"usage.flag.headless":             "Executa o navegador sem janela (padrão: true com -output ou -count, false sem)",
+		"usage.flag.stealth":              "Ativa modo stealth para evitar detecção (padrão: true)",
+		"usage.flag.random-ua":            "Usa agente de usuário aleatório (padrão: true)",
+		"usage.flag.ua-file":              "Arquivo com um agente de usuário por linha para o -random-ua sortear",
+		"usage.flag.slow":                 "Pausa antes de cada ação no navegador, como cliques e digitação (padrão: 200ms)",
+		"usage.flag.abort-on-block":       "Interrompe a extração se a CAPES exibir bloqueio ou captcha (padrão: true)",
+		"usage.flag.throttle-factor":      "Aumenta o delay quando as páginas ficam N vezes mais lentas (padrão: 2; <= 1 desativa)",
+		"usage.flag.throttle-max-delay":   "Delay extra máximo adicionado pela autorregulação (padrão: 60s)",
+		"usage.flag.retry-initial-delay":  "Espera antes de repetir um carregamento que falhou; dobra a cada tentativa (padrão: 1s)",
+		"usage.flag.retry-max-delay":      "Espera máxima entre tentativas de carregamento (padrão: 30s)",
+		"usage.flag.deadline":             "Parar na próxima página após este horário e exportar o parcial (ex: '2h', '06:00')",
+		"usage.flag.pause-file":           "Pausar entre páginas enquanto este arquivo existir (ex: 'pausar.txt')",
+		"usage.flag.poll-interval":        "Intervalo entre verificações da página ao aguardar elementos (padrão: 200ms)",
+		"usage.flag.element-timeout":      "Tempo máximo para localizar cada elemento da página (padrão: 5s)",
+		"usage.flag.chrome-path":          "Executável do Chrome/Chromium a usar em vez do baixado automaticamente",
+		"usage.flag.profile":              "Pasta de perfil do navegador mantida entre execuções (cookies e armazenamento local); uma execução por vez",
+		"usage.flag.window":               "Tamanho da janela e da área visível do navegador, LARGURAxALTURA (padrão: 1920x1080)",
+		"usage.flag.kill-orphans":         "Encerrar processos do Chromium deixados por execuções anteriores interrompidas",
+		"usage.flag.retry-on-empty-page":  "Recarrega páginas intermediárias sem resultados (padrão: true)",
+		"summary.responsible":             "Responsável",
+		"summary.database":                "Base de dados",
+		"summary.searchTerm":              "Termos de busca",
+		"summary.date":                    "Data da busca",
+		"summary.count":                   "No de artigos encontrados",
+		"summary.filters":                 "Filtros usados",
+		"summary.version":                 "Versão da ferramenta",
+		"summary.dateLayout":              "02/01/2006",
+		"summary.noResults":               "0 resultados",
+		"summary.filtersUnavailable":      "Filtros não disponíveis",
+		"summary.noFilters":               "Nenhum filtro aplicado",
+		"summary.yes":                     "Sim",
+		"summary.no":                      "Não",
+		"summary.openAccess":              "Acesso aberto: %s",
+		"summary.pubType":                 "Tipo de publicação: %s",
+		"summary.year":                    "Ano: %s",
+		"summary.yearRange":               "%d até %d",
+		"summary.yearUntil":               "Até %d",
+		"summary.peerReview":              "Revisão por pares: %s",
+		"summary.languages":               "Idiomas: %s",
+		"summary.areas":                   "Áreas de conhecimento: %s",
+		"summary.source":                  "Base: %s",
+		"summary.maxPages":                "Máximo de páginas: %d",
+		"summary.maxResults":              "Máximo de resultados: %d",
+		"summary.since":                   "Publicados desde: %s",
+	},
+	LocaleEN: {
+		"prompt.searchTerm":               "SEARCH TERMS",
+		"prompt.searchTermHint":           "free text (required)",
+		"prompt.required":                 "Required field. Please fill it in.",
+		"report.title":                    " SEARCH REPORT",
+		"report.searchTerm":               "Search terms:      ",
+		"report.access":                   "Open access:       ",
+		"report.pubType":                  "Publication type:   ",
+		"report.years":                    "Publication years:  ",
+		"report.yearRange":                "%s to %s",
+		"report.peerReview":               "Peer reviewed:      ",
+		"report.languages":                "Languages:          ",
+		"report.areas":                    "Knowledge areas:    ",
+		"report.source":                   "Database:           ",
+		"report.sort":                     "Sort order:         ",
+		"report.sortDefault":              "portal default",
+		"report.any":                      "any",
+		"report.all":                      "all",
+		"report.unspecified":              "not specified",
+		"report.exportEnabled":            "Result export: Enabled",
+		"report.outputFile":               "Output file: ",
+		"report.format":                   "Format: ",
+		"report.maxPages":                 "Maximum pages: ",
+		"report.abstracts":                "Abstracts: ",
+		"report.abstractsOAOnly":          "open access only",
+		"report.yes":                      "yes",
+		"report.maxResults":               "Maximum results: ",
+		"report.since":                    "Published since: ",
+		"report.sinceLastRun":             "the last run",
+		"report.details":                  "Detail pages: ",
+		"report.noDetails":                "skipped (title and link only)",
+		"report.includeHeaders":           "Include headers: ",
+		"report.pageDelay":                "Delay between pages: ",
+		"report.detailDelay":              "Delay between details: ",
+		"search.url":                      "Search URL:",
+		"check.failed":                    "FAIL",
+		"preflight.title":                 "Preflight:",
+		"preflight.passed":                "Preflight passed.",
+		"export.progress":                 "%s %3d%% page %d of %d, %d results, ~%v left\r",
+		"export.completed":                "Export completed:",
+		"export.pages":                    "- Pages processed: %d",
+		"export.results":                  "- Results exported: %d",
+		"export.file":                     "- File saved to: %s (%s)",
+		"export.duration":                 "- Total time: %s",
+		"export.throughput":               "- Throughput: %.1f results per minute",
+		"export.errors":                   "- Errors: %d (see the warnings in the log)",
+		"export.starting":                 "Starting result export to: %s",
+		"export.mayTakeMinutes":           "This may take a few minutes depending on the number of results...",
+		"export.noResults":                "The search completed but found no results (0 results).",
+		"export.interrupted":              "Extraction interrupted: %d results collected before the interruption were saved to: %s",
+		"export.resumeHint":               "Run the same command with -resume to continue where it stopped.",
+		"export.openHint":                 "You can open the CSV file in a spreadsheet editor such as Excel or LibreOffice Calc.",
+		"urlList.written":                 "List of %d page URLs written to: %s",
+		"dryRun.done":                     "Dry run (-dry-run): the browser was not launched.",
+		"count.result":                    "%d results (%d pages)",
+		"view.opening":                    "Opening browser with the search URL...",
+		"view.success":                    "Search completed successfully.",
+		"view.keepOpen":                   "Keeping the browser open for %s so you can view the results.",
+		"view.keepOpenUntilClosed":        "Keeping the browser open until you close the window (or press Ctrl+C).",
+		"error.config":                    "Configuration error: %v",
+		"error.input":                     "Input error: %v",
+		"error.browser":                   "Browser error: %v",
+		"error.application":               "Application error: %v",
+		"error.emptyResults":              "Export skipped: %v",
+		"error.network":                   "Network error: %v",
+		"error.unexpected":                "Unexpected error: %v",
+		"usage.title":                     "Usage: capes-search [flags]",
+		"usage.search":                    "Search flags:",
+		"usage.export":                    "Export flags:",
+		"usage.interface":                 "Interface flags:",
+		"usage.diagnostics":               "Diagnostic flags:",
+		"usage.antiBlock":                 "Anti-blocking flags:",
+		"usage.examples":                  "Examples:",
+		"usage.flagsOf":                   "Usage of %s:",
+		"usage.flag.search":               "Search term (e.g. 'inteligência artificial')",
+		"usage.flag.oa":                   "Open access: 'sim', 'nao' or omit for any",
+		"usage.flag.t":                    "Publication type (e.g. 'Artigo'); repeat for several types",
+		"usage.flag.pymin":                "Earliest publication year (e.g. 2010)",
+		"usage.flag.pymax":                "Latest publication year (e.g. 2023)",
+		"usage.flag.pr":                   "Peer reviewed: 'sim', 'nao' or omit for any",
+		"usage.flag.lang":                 "Language; repeat for several (e.g. -lang Português -lang Inglês) or separate with '/'",
+		"usage.flag.area":                 "Knowledge area; repeat for several (e.g. -area 'Ciências da Saúde')",
+		"usage.flag.source":               "Database (CAPES 'source' parameter; empty = all)",
+		"usage.flag.query-mode":           "How the term is sent: 'simple' (default, loose words), 'exact' or 'boolean':\n            -search \"saúde mental\" -query-mode exact        searches the exact phrase\n            -search \"(ansiedade OR depressão) AND adolescentes NOT adultos\" -query-mode boolean",
+		"usage.flag.sort":                 "Sort order: 'relevance', 'date_desc' (newest first), 'date_asc' or 'title' (default: the portal's)",
+		"usage.flag.config":               "YAML or JSON file with the search parameters (e.g. 'revisoes/vacinas.yaml')",
+		"usage.flag.output":               "File to save the results to (e.g. 'resultados.csv')",
+		"usage.flag.format":               "Export format: 'csv', 'xlsx' (Excel), 'bibtex', 'markdown', 'html', 'ris' (EndNote) or 'ndjson' (one JSON object per line);\n              separate several with commas to export them all at once (e.g. 'csv,bibtex')",
+		"usage.flag.max-pages":            "Maximum number of pages to process (0 = all)",
+		"usage.flag.page-range":           "Range of pages to process (e.g. '5-10'); takes precedence over -max-pages",
+		"usage.flag.no-headers":           "Don't include headers in the CSV file",
+		"usage.flag.max-results":          "Extract only the first N results (0 = all; combines with -max-pages)",
+		"usage.flag.abstracts":            "Extract the abstract of each result ('Resumo' column)",
+		"usage.flag.abstracts-oa-only":    "With -abstracts, extract abstracts of open access items only",
+		"usage.flag.no-details":           "Don't visit each publication's page: much faster, but exports only title and link",
+		"usage.flag.normalize-authors":    "Fill the 'Autor (normalizado)' column in 'Surname, Given' form",
+		"usage.flag.author-separator":     "Separator between authors in the 'Autor' column (default: '; ')",
+		"usage.flag.title-dedup-distance": "Remove near-identical titles from the same year (e.g. 3; 0 = disabled)",
+		"usage.flag.author":               "Keep only results by this author, ignoring case and accents; repeat for several",
+		"usage.flag.since":                "Incremental update: only results published since the date (e.g. '2024', '2024-03-01') or\n              since the last run ('last'), skipping those already exported ('<output>.lastrun')",
+		"usage.flag.dump-url-list":        "Write the URL of each search page to a file, without extracting results",
+		"usage.flag.pages":                "Number of pages for -dump-url-list (0 = ask CAPES for the total)",
+		"usage.flag.on-existing":          "If the output file exists: overwrite (default), fail, backup or timestamp",
+		"usage.flag.append":               "Append the results to an existing CSV (same columns), without repeating publications",
+		"usage.flag.columns":              "CSV/XLSX columns and their order (e.g. 'titulo,autor,ano,doi,link'); values:\n              titulo, autor, autor_normalizado, ano, periodico, link, doi, issn, editora, acesso_aberto,\n              resumo, citacao, palavras_chave, fontes",
+		"usage.flag.bom":                  "Start the CSV with the UTF-8 BOM so Excel shows accents (default: true on Windows)",
+		"usage.flag.delimiter":            "CSV field separator: 'comma' (default), 'semicolon', 'tab' or a single character",
+		"usage.flag.quote-all":            "Quote every CSV field",
+		"usage.flag.compress":             "Compress the exported file with gzip (adds '.gz' to the name)",
+		"usage.flag.no-export-on-empty":   "Don't overwrite the output file when the search comes back empty (exit code 4)",
+		"usage.flag.min-results":          "Don't overwrite the output file below N results (exit code 4)",
+		"usage.flag.stream":               "Write each page's results as soon as they are extracted, saving memory on large searches",
+		"usage.flag.resume":               "Continue an interrupted export from its checkpoint ('<output>.checkpoint')",
+		"usage.flag.json-summary":         "Print a JSON summary of the run on stdout (logs go to stderr)",
+		"usage.flag.lang-ui":              "Language of messages, the report and the summary CSV: 'pt' (default) or 'en'; without the flag, follows LANG",
+		"usage.flag.quiet":                "Don't show the export progress bar (useful in scripts)",
+		"usage.flag.view-time":            "Without -output, how long the browser stays open (default: 30s; 0 = until the window is closed)",
+		"usage.flag.log-format":           "Log format: 'text' (default) or 'json' (one object per line)",
+		"usage.flag.log-level":            "Minimum log level: 'debug', 'info' (default), 'warn' or 'error'",
+		"usage.flag.log-file":             "Also write the logs to this file, while still showing them in the terminal",
+		"usage.flag.error-json":           "On failure, print a JSON object {type, code, message} to stderr instead of the message (for scripts)",
+		"usage.flag.preflight":            "Check CAPES and the selectors before extracting",
+		"usage.flag.preflight-only":       "Only run the preflight check and exit",
+		"usage.flag.dry-run":              "Show the report and the search URL without opening the browser",
+		"usage.flag.count":                "Only show how many results and pages the search returns, without extracting",
+		"usage.flag.screenshot-on-empty":  "Save a screenshot of pages without results to debug/",
+		"usage.flag.version":              "Show the version, commit and build date and exit",
+		"usage.flag.delay":                "Wait between pages to avoid being blocked (e.g. '5s', '10s')",
+		"usage.flag.detail-delay":         "Average wait between detail pages, varying ±30% (e.g. '1s'; default: 0)",
+		"usage.flag.headless":             "Run the browser without a window (default: true with -output or -count, false without)",
+		"usage.flag.stealth":              "Enable stealth mode to avoid detection (default: true)",
+		"usage.flag.random-ua":            "Use a random user agent (default: true)",
+		"usage.flag.ua-file":              "File with one user agent per line for -random-ua to choose from",
+		"usage.flag.slow":                 "Pause before each browser action, such as clicks and typing (default: 200ms)",
+		"usage.flag.abort-on-block":       "Stop extracting if CAPES shows a block page or captcha (default: true)",
+		"usage.flag.throttle-factor":      "Increase the delay when pages get N times slower (default: 2; <= 1 disables)",
+		"usage.flag.throttle-max-delay":   "Maximum extra delay added by self-throttling (default: 60s)",
+		"usage.flag.retry-initial-delay":  "Wait before retrying a failed load; doubles on each attempt (default: 1s)",
+		"usage.flag.retry-max-delay":      "Maximum wait between load attempts (default: 30s)",
+		"usage.flag.deadline":             "Stop at the next page after this time and export what was collected (e.g. '2h', '06:00')",
+		"usage.flag.pause-file":           "Pause between pages while this file exists (e.g. 'pausar.txt')",
+		"usage.flag.poll-interval":        "Interval between page checks while waiting for elements (default: 200ms)",
+		"usage.flag.element-timeout":      "Maximum time to find each page element (default: 5s)",
+		"usage.flag.chrome-path":          "Chrome/Chromium executable to use instead of the auto-downloaded one",
+		"usage.flag.profile":              "Browser profile directory kept between runs (cookies and local storage); one run at a time",
+		"usage.flag.window":               "Browser window and viewport size, WIDTHxHEIGHT (default: 1920x1080)",
+		"usage.flag.kill-orphans":         "Kill Chromium processes left by earlier interrupted runs",
+		"usage.flag.retry-on-empty-page":  "Reload intermediate pages without results (default: true)",
+		"summary.responsible":             "Responsible",
+		"summary.database":                "Database",
+		"summary.searchTerm":              "Search terms",
+		"summary.date":                    "Search date",
+		"summary.count":                   "No. of articles found",
+		"summary.filters":                 "Filters used",
+		"summary.version":                 "Tool version",
+		"summary.dateLayout":              "2006-01-02",
+		"summary.noResults":               "0 results",
+		"summary.filtersUnavailable":      "Filters not available",
+		"summary.noFilters":               "No filters applied",
+		"summary.yes":                     "Yes",
+		"summary.no":                      "No",
+		"summary.openAccess":              "Open access: %s",
+		"summary.pubType":                 "Publication type: %s",
+		"summary.year":                    "Year: %s",
+		"summary.yearRange":               "%d to %d",
+		"summary.yearUntil":               "Up to %d",
+		"summary.peerReview":              "Peer reviewed: %s",
+		"summary.languages":               "Languages: %s",
+		"summary.areas":                   "Knowledge areas: %s",
+		"summary.source":                  "Source: %s",
+		"summary.maxPages":                "Maximum pages: %d",
+		"summary.maxResults":              "Maximum results: %d",
+		"summary.since":                   "Published since: %s",
+	},
+}
@@ -2,6 +2,7 @@
 package logger
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -20,6 +21,17 @@ const (
 	ERROR
 )
 
+// Format selects how log lines are written
+type Format int
+
+// Log format constants
+const (
+	// Text writes "2006-01-02 15:04:05 [INFO ] Prefix: message" lines
+	Text Format = iota
+	// JSON writes one object per line with timestamp, level, prefix and message keys
+	JSON
+)
+
 // Logger defines the interface for logging
 type Logger interface {
 	// Debug logs a debug message
@@ -48,6 +60,7 @@ type SimpleLogger struct {
 	prefix     string
 	showTime   bool
 	timeFormat string
+	format     Format
 }
 
 // LoggerOption defines functional options for configuring the logger
@@ -100,6 +113,13 @@ func WithTimeFormat(format string) LoggerOption {
 	}
 }
 
+// WithFormat sets the format of log lines (Text by default)
+func WithFormat(format Format) LoggerOption {
+	return func(l *SimpleLogger) {
+		l.format = format
+	}
+}
+
 // WithoutTime disables timestamp in log messages
 func WithoutTime() LoggerOption {
 	return func(l *SimpleLogger) {
@@ -107,6 +127,23 @@ func WithoutTime() LoggerOption {
 	}
 }
 
+// ParseLevel converts a level name ("debug", "info", "warn" or "error", in
+// any case) to a LogLevel
+func ParseLevel(name string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return DEBUG, nil
+	case "info":
+		return INFO, nil
+	case "warn", "warning":
+		return WARN, nil
+	case "error":
+		return ERROR, nil
+	default:
+		return INFO, fmt.Errorf("unknown log level %q (must be 'debug', 'info', 'warn' or 'error')", name)
+	}
+}
+
 // levelString returns a string representation of the log level
 func levelString(level LogLevel) string {
 	switch level {
@@ -130,6 +167,11 @@ func (l *SimpleLogger) log(level LogLevel, format string, args ...interface{}) {
 		return
 	}
 
+	if l.format == JSON {
+		l.logJSON(level, fmt.Sprintf(format, args...))
+		return
+	}
+
 	// Build log message
 	var message strings.Builder
 
@@ -156,6 +198,32 @@ func (l *SimpleLogger) log(level LogLevel, format string, args ...interface{}) {
 	fmt.Fprintln(l.writer, message.String())
 }
 
+// jsonLine is a log line in JSON format; the field order is the key order
+type jsonLine struct {
+	Timestamp string `json:"timestamp,omitempty"`
+	Level     string `json:"level"`
+	Prefix    string `json:"prefix"`
+	Message   string `json:"message"`
+}
+
+// logJSON writes message as a single JSON object
+// Timestamps use RFC 3339 regardless of the text time format, since they are
+// meant to be parsed
+func (l *SimpleLogger) logJSON(level LogLevel, message string) {
+	line := jsonLine{
+		Level:   strings.TrimSpace(levelString(level)),
+		Prefix:  l.prefix,
+		Message: message,
+	}
+	if l.showTime {
+		line.Timestamp = time.Now().Format(time.RFC3339)
+	}
+
+	// Marshalling a struct of strings can't fail
+	data, _ := json.Marshal(line)
+	fmt.Fprintln(l.writer, string(data))
+}
+
 // Debug logs a debug message
 func (l *SimpleLogger) Debug(format string, args ...interface{}) {
 	l.log(DEBUG, format, args...)
@@ -185,6 +253,7 @@ func (l *SimpleLogger) WithPrefix(prefix string) Logger {
 		prefix:     prefix,
 		showTime:   l.showTime,
 		timeFormat: l.timeFormat,
+		format:     l.format,
 	}
 
 	return newLogger
@@ -208,6 +277,19 @@ func FileLogger(filename string, options ...LoggerOption) (Logger, error) {
 	return NewLogger(allOptions...), nil
 }
 
+// Close closes the writer of a logger created with FileLogger
+// Loggers writing to stdout, stderr or a writer that can't be closed are left
+// untouched, so Close is safe to call on any SimpleLogger
+func (l *SimpleLogger) Close() error {
+	if l.writer == os.Stdout || l.writer == os.Stderr {
+		return nil
+	}
+	if closer, ok := l.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 // MultiLogger creates a logger that writes to multiple outputs
 func MultiLogger(loggers ...Logger) Logger {
 	return &multiLogger{loggers: loggers}
@@ -260,4 +342,17 @@ func (m *multiLogger) SetLevel(level LogLevel) {
 	for _, logger := range m.loggers {
 		logger.SetLevel(level)
 	}
+}
+
+// Close closes every logger that can be closed, returning the first error
+func (m *multiLogger) Close() error {
+	var firstErr error
+	for _, logger := range m.loggers {
+		if closer, ok := logger.(io.Closer); ok {
+			if err := closer.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
 }
\ No newline at end of file
@@ -0,0 +1,128 @@
+package result
+
+import (
+	"strings"
+	"unicode"
+)
+
+// surnameParticles are lowercase connectors that belong to the surname
+// when they precede it (e.g. "da Silva", "van der Berg")
+var surnameParticles = map[string]bool{
+	"da": true, "das": true, "de": true, "del": true, "della": true,
+	"di": true, "do": true, "dos": true, "du": true, "e": true,
+	"la": true, "le": true, "van": true, "von": true, "der": true,
+}
+
+// surnameSuffixes are generational suffixes kept together with the surname
+var surnameSuffixes = map[string]bool{
+	"filho": true, "neto": true, "sobrinho": true, "junior": true,
+	"júnior": true, "jr": true, "jr.": true,
+}
+
+// institutionalKeywords mark corporate authors, which are never reordered
+var institutionalKeywords = []string{
+	"universidade", "university", "universidad", "instituto", "institute",
+	"ministério", "ministry", "associação", "association", "sociedade",
+	"society", "fundação", "foundation", "organização", "organization",
+	"centro", "center", "centre", "departamento", "department", "grupo",
+	"group", "comitê", "committee", "consortium", "consórcio",
+	"collaboration", "network", "rede", "conselho", "council",
+}
+
+// NormalizeAuthorNames converts each author name to "Surname, Given" form
+func NormalizeAuthorNames(authors []string) []string {
+	normalized := make([]string, 0, len(authors))
+	for _, author := range authors {
+		if name := NormalizeAuthorName(author); name != "" {
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized
+}
+
+// NormalizeAuthorName converts a single author name to "Surname, Given" form
+// Names already in "Surname, Given" form only get their casing fixed, while
+// single names and institutional authors are returned unchanged
+func NormalizeAuthorName(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || isInstitutionalAuthor(name) {
+		return name
+	}
+
+	// "SILVA, João" is already ordered, only the casing needs fixing
+	if surname, given, found := strings.Cut(name, ","); found {
+		surname = strings.TrimSpace(surname)
+		given = strings.TrimSpace(given)
+		if given == "" {
+			return fixNameCase(surname)
+		}
+		return fixNameCase(surname) + ", " + fixNameCase(given)
+	}
+
+	parts := strings.Fields(name)
+	if len(parts) == 1 {
+		return fixNameCase(name)
+	}
+
+	// Walk back from the last word, pulling suffixes and particles into the surname
+	start := len(parts) - 1
+	if surnameSuffixes[strings.ToLower(parts[start])] && start > 1 {
+		start--
+	}
+	for start > 1 && surnameParticles[strings.ToLower(parts[start-1])] {
+		start--
+	}
+
+	surname := strings.Join(parts[start:], " ")
+	given := strings.Join(parts[:start], " ")
+	return fixNameCase(surname) + ", " + fixNameCase(given)
+}
+
+// isInstitutionalAuthor reports whether name looks like a corporate author
+func isInstitutionalAuthor(name string) bool {
+	lower := strings.ToLower(name)
+	for _, keyword := range institutionalKeywords {
+		for _, word := range strings.Fields(lower) {
+			if strings.Trim(word, ".,;()") == keyword {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fixNameCase title-cases words written entirely in upper case, keeping
+// particles in lower case. Mixed-case words and initials are left alone
+func fixNameCase(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		if !isUpperWord(word) || len([]rune(word)) <= 2 && strings.HasSuffix(word, ".") {
+			continue
+		}
+
+		lower := strings.ToLower(word)
+		if surnameParticles[lower] && i < len(words)-1 {
+			words[i] = lower
+			continue
+		}
+
+		runes := []rune(lower)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
+
+// isUpperWord reports whether every letter in word is upper case
+func isUpperWord(word string) bool {
+	hasLetter := false
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			hasLetter = true
+			if !unicode.IsUpper(r) {
+				return false
+			}
+		}
+	}
+	return hasLetter
+}
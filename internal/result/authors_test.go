@@ -0,0 +1,49 @@
+package result
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeAuthorName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		// Already "Surname, Given", only the casing changes
+		{"SILVA, João", "Silva, João"},
+		{"Santos, M. A.", "Santos, M. A."},
+		// "Given Surname" is reordered
+		{"João Silva", "Silva, João"},
+		{"J. Silva", "Silva, J."},
+		{"  João   SILVA ", "Silva, João"},
+		{"Maria da Conceição Santos", "Santos, Maria da Conceição"},
+		// Particles and suffixes stay with the surname
+		{"Ana Paula de Souza", "de Souza, Ana Paula"},
+		{"JOÃO DA SILVA", "da Silva, João"},
+		{"Ludwig van Beethoven", "van Beethoven, Ludwig"},
+		{"José Pereira Filho", "Pereira Filho, José"},
+		// Single names are kept
+		{"Pelé", "Pelé"},
+		{"MADONNA", "Madonna"},
+		// Corporate authors are left untouched
+		{"Universidade de São Paulo", "Universidade de São Paulo"},
+		{"WORLD HEALTH ORGANIZATION", "WORLD HEALTH ORGANIZATION"},
+		{"Grupo de Pesquisa em Solos (GPS)", "Grupo de Pesquisa em Solos (GPS)"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeAuthorName(tt.name); got != tt.want {
+			t.Errorf("NormalizeAuthorName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeAuthorNamesDropsEmpty(t *testing.T) {
+	got := NormalizeAuthorNames([]string{"João Silva", "  ", "Instituto Butantan"})
+	want := []string{"Silva, João", "Instituto Butantan"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeAuthorNames() = %v, want %v", got, want)
+	}
+}
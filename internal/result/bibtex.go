@@ -0,0 +1,282 @@
+package result
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/alexandreffaria/reviu/internal/errors"
+	"github.com/alexandreffaria/reviu/internal/logger"
+	"github.com/alexandreffaria/reviu/internal/textnorm"
+)
+
+// bibTeXEntryTypes maps CAPES publication types to BibTeX entry types
+// Anything else, including an unknown type, becomes @misc
+var bibTeXEntryTypes = map[string]string{
+	"Artigo":            "article",
+	"Revisão":           "article",
+	"Editorial":         "article",
+	"Carta":             "article",
+	"Capítulo de livro": "incollection",
+}
+
+// bibTeXEscaper escapes the characters that are special in BibTeX
+var bibTeXEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`%`, `\%`,
+	`&`, `\&`,
+	`_`, `\_`,
+	`#`, `\#`,
+	`$`, `\$`,
+)
+
+// BibTeXWriter implements ResultWriter for BibTeX, for import into
+// reference managers such as Zotero or Mendeley
+type BibTeXWriter struct {
+	config   ExportConfig
+	file     io.WriteCloser
+	writer   *bufio.Writer
+	log      logger.Logger
+	keys     map[string]int
+	rowCount int
+}
+
+// NewBibTeXWriter creates a new BibTeX writer
+func NewBibTeXWriter(config ExportConfig, log logger.Logger) (*BibTeXWriter, error) {
+	if config.FilePath == "" {
+		return nil, errors.NewConfigError("file path is required for BibTeX export", nil)
+	}
+
+	if log == nil {
+		log = logger.NewLogger() // Default logger
+	}
+
+	return &BibTeXWriter{
+		config: config,
+		log:    log.WithPrefix("BibTeXExport"),
+		keys:   make(map[string]int),
+	}, nil
+}
+
+// Initialize opens the file and prepares the writer
+func (w *BibTeXWriter) Initialize() error {
+	file, err := openExportFile(&w.config, w.log)
+	if err != nil {
+		return err
+	}
+
+	w.file = file
+	w.writer = bufio.NewWriter(file)
+	w.log.Info("BibTeX export initialized: %s", w.config.FilePath)
+	return nil
+}
+
+// WriteHeader does nothing, BibTeX has no header
+func (w *BibTeXWriter) WriteHeader() error {
+	return nil
+}
+
+// WriteResult writes a single search result as a BibTeX entry
+func (w *BibTeXWriter) WriteResult(r SearchResult) error {
+	if w.writer == nil {
+		return errors.NewConfigError("BibTeX writer not initialized, call Initialize first", nil)
+	}
+
+	entryType := bibTeXEntryType(w.config.PublicationTypes)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "@%s{%s,\n", entryType, w.citationKey(r))
+	// Extra braces keep reference managers from changing the capitalization
+	fmt.Fprintf(&sb, "  title = {{%s}},\n", escapeBibTeX(r.Title))
+	if authors := bibTeXAuthors(r); authors != "" {
+		fmt.Fprintf(&sb, "  author = {%s},\n", authors)
+	}
+	if r.Year != "" {
+		fmt.Fprintf(&sb, "  year = {%s},\n", escapeBibTeX(r.Year))
+	}
+	if r.Journal != "" && entryType == "article" {
+		fmt.Fprintf(&sb, "  journal = {%s},\n", escapeBibTeX(r.Journal))
+	}
+	if r.ISSN != "" && entryType == "article" {
+		fmt.Fprintf(&sb, "  issn = {%s},\n", r.ISSN)
+	}
+	if r.Publisher != "" {
+		fmt.Fprintf(&sb, "  publisher = {%s},\n", escapeBibTeX(r.Publisher))
+	}
+	if r.DOI != "" {
+		fmt.Fprintf(&sb, "  doi = {%s},\n", r.DOI)
+	}
+	if r.URL != "" {
+		if entryType == "misc" {
+			fmt.Fprintf(&sb, "  howpublished = {\\url{%s}},\n", r.URL)
+		} else {
+			fmt.Fprintf(&sb, "  url = {%s},\n", r.URL)
+		}
+	}
+	if len(r.Keywords) > 0 {
+		fmt.Fprintf(&sb, "  keywords = {%s},\n", escapeBibTeX(strings.Join(r.Keywords, ", ")))
+	}
+	if r.Abstract != "" {
+		fmt.Fprintf(&sb, "  abstract = {%s},\n", escapeBibTeX(r.Abstract))
+	}
+	sb.WriteString("}\n\n")
+
+	if _, err := w.writer.WriteString(sb.String()); err != nil {
+		return errors.NewExternalError("failed to write BibTeX entry", err)
+	}
+
+	w.rowCount++
+	return nil
+}
+
+// WriteResults writes multiple results, checking for cancellation between entries
+func (w *BibTeXWriter) WriteResults(ctx context.Context, results []SearchResult) error {
+	for _, r := range results {
+		if err := ctx.Err(); err != nil {
+			w.writer.Flush()
+			w.log.Warn("BibTeX export cancelled after %d entries", w.rowCount)
+			return err
+		}
+
+		if err := w.WriteResult(r); err != nil {
+			return err
+		}
+	}
+
+	return w.writer.Flush()
+}
+
+// WriteCollection writes an entire search collection
+func (w *BibTeXWriter) WriteCollection(ctx context.Context, collection *SearchCollection) error {
+	if collection == nil {
+		return errors.NewConfigError("search collection cannot be nil", nil)
+	}
+
+	if err := w.WriteResults(ctx, collection.Results); err != nil {
+		return err
+	}
+
+	w.log.Info("Wrote %d search results to BibTeX", collection.TotalResults)
+	return nil
+}
+
+// Close flushes and closes the file
+func (w *BibTeXWriter) Close() error {
+	if w.writer == nil {
+		return nil // Nothing to close
+	}
+
+	if err := w.writer.Flush(); err != nil {
+		return errors.NewExternalError("error flushing BibTeX data", err)
+	}
+
+	if err := w.file.Close(); err != nil {
+		return errors.NewExternalError("error closing BibTeX file", err)
+	}
+
+	w.log.Info("BibTeX export completed: %s (%d entries)", w.config.FilePath, w.rowCount)
+	return nil
+}
+
+// Path returns the file the entries are written to
+func (w *BibTeXWriter) Path() string {
+	return w.config.FilePath
+}
+
+// citationKey builds a key from the first author's surname and the year,
+// adding a letter when the same key was already used ("silva2020a")
+func (w *BibTeXWriter) citationKey(r SearchResult) string {
+	base := bibTeXKeyPart(firstAuthorSurname(r))
+	if base == "" {
+		base = "anon"
+	}
+	base += bibTeXKeyPart(r.Year)
+
+	count := w.keys[base]
+	w.keys[base] = count + 1
+	if count == 0 {
+		return base
+	}
+	return base + disambiguationSuffix(count)
+}
+
+// bibTeXEntryType returns the entry type shared by all publication types the
+// search was filtered by, or "misc" when they differ or are unknown
+func bibTeXEntryType(pubTypes []string) string {
+	entryType := ""
+	for _, pubType := range pubTypes {
+		mapped, ok := bibTeXEntryTypes[pubType]
+		if !ok || (entryType != "" && mapped != entryType) {
+			return "misc"
+		}
+		entryType = mapped
+	}
+
+	if entryType == "" {
+		return "misc"
+	}
+	return entryType
+}
+
+// disambiguationSuffix returns "a" for 1, "b" for 2, ..., "z", then "aa"
+func disambiguationSuffix(n int) string {
+	suffix := ""
+	for n > 0 {
+		n--
+		suffix = string(rune('a'+n%26)) + suffix
+		n /= 26
+	}
+	return suffix
+}
+
+// firstAuthorSurname returns the surname of the first author of r
+func firstAuthorSurname(r SearchResult) string {
+	first := r.Author
+	if len(r.Authors) > 0 {
+		first = r.Authors[0]
+	}
+	if first == "" {
+		return ""
+	}
+
+	surname, _, _ := strings.Cut(NormalizeAuthorName(first), ",")
+	return surname
+}
+
+// bibTeXKeyPart lowercases s, strips its accents and keeps only ASCII
+// letters and digits
+func bibTeXKeyPart(s string) string {
+	s = textnorm.Normalize(s)
+	return strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// bibTeXAuthors joins the authors of r with " and " as BibTeX expects
+func bibTeXAuthors(r SearchResult) string {
+	authors := r.Authors
+	if len(authors) == 0 && r.Author != "" {
+		authors = []string{r.Author}
+	}
+
+	escaped := make([]string, 0, len(authors))
+	for _, author := range authors {
+		if author = strings.TrimSpace(author); author != "" {
+			escaped = append(escaped, escapeBibTeX(author))
+		}
+	}
+	return strings.Join(escaped, " and ")
+}
+
+// escapeBibTeX escapes special characters and collapses whitespace
+func escapeBibTeX(s string) string {
+	return bibTeXEscaper.Replace(strings.Join(strings.Fields(s), " "))
+}
@@ -0,0 +1,80 @@
+package result
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/alexandreffaria/reviu/internal/errors"
+)
+
+// checkpointExtension is appended to the output file to name its checkpoint
+const checkpointExtension = ".checkpoint"
+
+// Checkpoint is the progress of an extraction, saved after every page so an
+// interrupted run can pick up where it stopped
+type Checkpoint struct {
+	SearchURL  string            `json:"searchURL"`
+	LastPage   int               `json:"lastPage"`
+	TotalFound int               `json:"totalFound"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
+	Results    []SearchResult    `json:"results"`
+	Errors     []ExtractionError `json:"errors"`
+}
+
+// CheckpointPath returns the checkpoint file for an export to outputFile
+func CheckpointPath(outputFile string) string {
+	return outputFile + checkpointExtension
+}
+
+// saveCheckpoint writes the state of collection after lastPage to path
+// The file is replaced atomically so a crash never leaves half a checkpoint
+func saveCheckpoint(path, searchURL string, lastPage int, collection *SearchCollection) error {
+	checkpoint := Checkpoint{
+		SearchURL:  searchURL,
+		LastPage:   lastPage,
+		TotalFound: collection.TotalFound,
+		UpdatedAt:  time.Now(),
+		Results:    collection.Results,
+		Errors:     collection.Errors,
+	}
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return errors.NewError(errors.Unknown, "failed to encode checkpoint", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return errors.NewExternalError("failed to write checkpoint", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.NewExternalError("failed to replace checkpoint", err)
+	}
+	return nil
+}
+
+// loadCheckpoint reads the checkpoint at path
+// A missing file returns nil without an error
+func loadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.NewExternalError("failed to read checkpoint", err)
+	}
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, errors.NewExternalError("checkpoint file is corrupt", err)
+	}
+	return &checkpoint, nil
+}
+
+// RemoveCheckpoint deletes the checkpoint at path, if there is one
+func RemoveCheckpoint(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.NewExternalError("failed to remove checkpoint", err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package result
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alexandreffaria/reviu/internal/errors"
+)
+
+// exportColumn maps a column of tabular exports (CSV and XLSX) to the
+// SearchResult field it shows
+type exportColumn struct {
+	name   string // Name used with -columns
+	header string // Header cell
+	value  func(c ExportConfig, r SearchResult) string
+}
+
+// exportColumns lists every column that can be exported, in the default
+// order. New extractable fields only need an entry here
+var exportColumns = []exportColumn{
+	{"titulo", "Título", func(c ExportConfig, r SearchResult) string { return r.Title }},
+	{"autor", "Autor", func(c ExportConfig, r SearchResult) string { return c.authorCell(r) }},
+	{"autor_normalizado", "Autor (normalizado)", func(c ExportConfig, r SearchResult) string { return r.NormalizedAuthor }},
+	{"ano", "Ano", func(c ExportConfig, r SearchResult) string { return r.Year }},
+	{"periodico", "Periódico", func(c ExportConfig, r SearchResult) string { return r.Journal }},
+	{"link", "Link de acesso", func(c ExportConfig, r SearchResult) string { return r.URL }},
+	{"doi", "DOI", func(c ExportConfig, r SearchResult) string { return r.DOI }},
+	{"issn", "ISSN", func(c ExportConfig, r SearchResult) string { return r.ISSN }},
+	{"editora", "Editora", func(c ExportConfig, r SearchResult) string { return r.Publisher }},
+	{"acesso_aberto", "Acesso aberto", func(c ExportConfig, r SearchResult) string { return r.OpenAccessLabel() }},
+	{"resumo", "Resumo", func(c ExportConfig, r SearchResult) string { return r.Abstract }},
+	{"citacao", "Citação", func(c ExportConfig, r SearchResult) string { return r.Citation }},
+	{"palavras_chave", "Palavras-chave", func(c ExportConfig, r SearchResult) string {
+		return strings.Join(r.Keywords, KeywordSeparator)
+	}},
+	{"fontes", ProvenanceHeader, func(c ExportConfig, r SearchResult) string {
+		return strings.Join(r.Provenance, ProvenanceSeparator)
+	}},
+}
+
+// DefaultColumns are the columns exported when none are chosen
+var DefaultColumns = []string{
+	"titulo", "autor", "autor_normalizado", "ano", "periodico", "link", "doi", "issn", "editora", "acesso_aberto",
+	"resumo", "citacao", "palavras_chave",
+}
+
+// ListPageColumns are the columns filled from the results page alone, the
+// default when detail pages are skipped
+var ListPageColumns = []string{"titulo", "link"}
+
+// detailOnlyColumns returns the columns in names that are filled from the
+// detail pages, which stay empty when those are skipped
+func detailOnlyColumns(names []string) []string {
+	var detail []string
+	for _, name := range names {
+		if !slices.Contains(ListPageColumns, name) && name != "fontes" {
+			detail = append(detail, name)
+		}
+	}
+	return detail
+}
+
+// KeywordSeparator joins the keywords of a result in a single cell
+const KeywordSeparator = "; "
+
+// ColumnNames returns the names accepted by -columns, in the default order
+func ColumnNames() []string {
+	names := make([]string, len(exportColumns))
+	for i, column := range exportColumns {
+		names[i] = column.name
+	}
+	return names
+}
+
+// lookupColumn returns the column called name
+func lookupColumn(name string) (exportColumn, bool) {
+	for _, column := range exportColumns {
+		if column.name == name {
+			return column, true
+		}
+	}
+	return exportColumn{}, false
+}
+
+// columns returns the columns to export: Columns, or DefaultColumns when
+// empty, plus the provenance column when requested and not already chosen
+func (c ExportConfig) columns() (columnSet, error) {
+	names := c.Columns
+	if len(names) == 0 {
+		names = DefaultColumns
+	}
+	if c.IncludeProvenance && !slices.Contains(names, "fontes") {
+		names = append(append([]string(nil), names...), "fontes")
+	}
+
+	columns := make(columnSet, 0, len(names))
+	for _, name := range names {
+		column, ok := lookupColumn(name)
+		if !ok {
+			return nil, errors.NewConfigError(
+				fmt.Sprintf("unknown export column: %s (valid columns: %s)", name, strings.Join(ColumnNames(), ", ")),
+				nil,
+			)
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// columnSet is the ordered list of columns a writer exports
+type columnSet []exportColumn
+
+// header returns the header cells of the columns
+func (s columnSet) header() []string {
+	header := make([]string, len(s))
+	for i, column := range s {
+		header[i] = column.header
+	}
+	return header
+}
+
+// row returns the cells of r, one per column
+func (s columnSet) row(c ExportConfig, r SearchResult) []string {
+	row := make([]string, len(s))
+	for i, column := range s {
+		row[i] = column.value(c, r)
+	}
+	return row
+}
+
+// headersFor returns the header cells of the named columns
+func headersFor(names []string) []string {
+	headers := make([]string, 0, len(names))
+	for _, name := range names {
+		if column, ok := lookupColumn(name); ok {
+			headers = append(headers, column.header)
+		}
+	}
+	return headers
+}
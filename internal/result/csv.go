@@ -1,40 +1,59 @@
 package result
 
 import (
+	"bufio"
+	"context"
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/alexandreffaria/reviu/internal/config"
 	"github.com/alexandreffaria/reviu/internal/errors"
+	"github.com/alexandreffaria/reviu/internal/i18n"
 	"github.com/alexandreffaria/reviu/internal/logger"
+	"github.com/alexandreffaria/reviu/internal/version"
 )
 
-// CSVHeader defines the column names for the CSV export
-var CSVHeader = []string{
-	"Título",
-	"Autor",
-	"Ano",
-	"Link de acesso",
+// CSVHeader defines the column names for the CSV export when no columns
+// are chosen with -columns
+var CSVHeader = headersFor(DefaultColumns)
+
+// utf8BOM is the UTF-8 byte order mark (EF BB BF)
+const utf8BOM = "\uFEFF"
+
+// ProvenanceHeader names the extra column of merged master exports
+const ProvenanceHeader = "Fontes"
+
+// summaryCSVColumns are the message IDs of the summary CSV column names
+var summaryCSVColumns = []string{
+	"summary.responsible",
+	"summary.database",
+	"summary.searchTerm",
+	"summary.date",
+	"summary.count",
+	"summary.filters",
+	"summary.version",
 }
 
-// SummaryCSVHeader defines the column names for the summary CSV export
-var SummaryCSVHeader = []string{
-	"Responsável",
-	"Base de dados",
-	"Termos de busca",
-	"Data da busca",
-	"No de artigos encontrados",
-	"Filtros usados",
+// SummaryCSVHeader returns the column names of the summary CSV in locale
+func SummaryCSVHeader(locale i18n.Locale) []string {
+	header := make([]string, len(summaryCSVColumns))
+	for i, key := range summaryCSVColumns {
+		header[i] = i18n.Message(locale, key)
+	}
+	return header
 }
 
 // CSVWriter implements ResultWriter for CSV format
 type CSVWriter struct {
 	config        ExportConfig
-	file          *os.File
-	writer        *csv.Writer
+	columns       columnSet
+	file          io.WriteCloser
+	writer        csvRecordWriter
 	log           logger.Logger
 	rowCount      int
 	headerWritten bool
@@ -50,36 +69,58 @@ func NewCSVWriter(config ExportConfig, log logger.Logger) (*CSVWriter, error) {
 		log = logger.NewLogger() // Default logger
 	}
 
+	columns, err := config.columns()
+	if err != nil {
+		return nil, err
+	}
+
 	return &CSVWriter{
-		config: config,
-		log:    log.WithPrefix("CSVExport"),
+		config:  config,
+		columns: columns,
+		log:     log.WithPrefix("CSVExport"),
 	}, nil
 }
 
 // Initialize opens the file and prepares the CSV writer
 func (w *CSVWriter) Initialize() error {
-	var err error
+	appending, err := w.appendTarget()
+	if err != nil {
+		return err
+	}
 
-	// Create directories if they don't exist
-	dir := filepath.Dir(w.config.FilePath)
-	if dir != "" && dir != "." {
-		if err = os.MkdirAll(dir, 0755); err != nil {
-			return errors.NewConfigError(fmt.Sprintf("failed to create directory %s", dir), err)
+	// Open file for writing
+	if appending {
+		w.file, err = os.OpenFile(w.config.FilePath, os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return errors.NewConfigError(fmt.Sprintf("failed to open %s for appending", w.config.FilePath), err)
+		}
+		w.headerWritten = true // The existing file already has it
+		w.log.Info("Appending to existing CSV: %s", w.config.FilePath)
+	} else {
+		w.file, err = openExportFile(&w.config, w.log)
+		if err != nil {
+			return err
 		}
 	}
 
-	// Open file for writing
-	w.file, err = os.Create(w.config.FilePath)
-	if err != nil {
-		return errors.NewConfigError(fmt.Sprintf("failed to create file %s", w.config.FilePath), err)
+	// Excel only reads the file as UTF-8 when it starts with a BOM
+	if w.config.WriteBOM && !appending {
+		if _, err := io.WriteString(w.file, utf8BOM); err != nil {
+			return errors.NewExternalError("failed to write UTF-8 BOM", err)
+		}
 	}
 
 	// Create CSV writer
-	w.writer = csv.NewWriter(w.file)
-
-	// Set delimiter if custom one is specified
-	if w.config.Delimiter != 0 && w.config.Delimiter != ',' {
-		w.writer.Comma = w.config.Delimiter
+	comma := ','
+	if w.config.Delimiter != 0 {
+		comma = w.config.Delimiter
+	}
+	if w.config.QuoteAll {
+		w.writer = newQuoteAllWriter(w.file, comma)
+	} else {
+		csvWriter := csv.NewWriter(w.file)
+		csvWriter.Comma = comma
+		w.writer = csvWriter
 	}
 
 	w.log.Info("CSV export initialized: %s", w.config.FilePath)
@@ -92,6 +133,124 @@ func (w *CSVWriter) Initialize() error {
 	return nil
 }
 
+// appendTarget reports whether the export should be appended to an existing
+// file: Append is set and the file has content. Appending is refused when the
+// file's header doesn't match the configured columns, since the new rows would
+// land under the wrong headings
+func (w *CSVWriter) appendTarget() (bool, error) {
+	if !w.config.Append {
+		return false, nil
+	}
+
+	info, err := os.Stat(w.config.FilePath)
+	if err != nil || info.Size() == 0 {
+		return false, nil // Nothing to append to, start a new file
+	}
+
+	file, err := os.Open(w.config.FilePath)
+	if err != nil {
+		return false, errors.NewConfigError(fmt.Sprintf("failed to open existing export %s", w.config.FilePath), err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	if w.config.Delimiter != 0 {
+		reader.Comma = w.config.Delimiter
+	}
+	header, err := reader.Read()
+	if err != nil {
+		return false, errors.NewConfigError(
+			fmt.Sprintf("cannot append to %s: its header could not be read with delimiter %q", w.config.FilePath, reader.Comma),
+			err,
+		)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], utf8BOM)
+	}
+
+	expected := w.columns.header()
+	if !slices.Equal(header, expected) {
+		return false, errors.NewConfigError(
+			fmt.Sprintf("cannot append to %s: its columns (%s) differ from the export columns (%s)",
+				w.config.FilePath, strings.Join(header, ", "), strings.Join(expected, ", ")),
+			nil,
+		)
+	}
+
+	return true, nil
+}
+
+// authorCell joins the authors of r with the configured separator, falling
+// back to the Author field for results built without an author list
+func (c ExportConfig) authorCell(r SearchResult) string {
+	if len(r.Authors) == 0 {
+		return r.Author
+	}
+
+	separator := c.AuthorSeparator
+	if separator == "" {
+		separator = DefaultAuthorSeparator
+	}
+	return strings.Join(r.Authors, separator)
+}
+
+// csvRecordWriter is the part of csv.Writer used by CSVWriter, so rows can
+// also go through quoteAllWriter
+type csvRecordWriter interface {
+	Write(record []string) error
+	Flush()
+	Error() error
+}
+
+// quoteAllWriter writes CSV records with every field quoted, which
+// csv.Writer can't do; quotes inside fields are doubled as usual
+type quoteAllWriter struct {
+	w     *bufio.Writer
+	comma rune
+	err   error
+}
+
+// newQuoteAllWriter creates a quoteAllWriter separating fields with comma
+func newQuoteAllWriter(w io.Writer, comma rune) *quoteAllWriter {
+	return &quoteAllWriter{w: bufio.NewWriter(w), comma: comma}
+}
+
+// Write writes a single record, ending it with a newline like csv.Writer
+func (q *quoteAllWriter) Write(record []string) error {
+	if q.err != nil {
+		return q.err
+	}
+
+	// bufio.Writer errors are sticky, so checking the last write is enough
+	for i, field := range record {
+		if i > 0 {
+			q.w.WriteRune(q.comma)
+		}
+		q.w.WriteByte('"')
+		q.w.WriteString(strings.ReplaceAll(field, `"`, `""`))
+		q.w.WriteByte('"')
+	}
+	q.err = q.w.WriteByte('\n')
+	return q.err
+}
+
+// Flush writes any buffered data to the underlying writer
+func (q *quoteAllWriter) Flush() {
+	if err := q.w.Flush(); err != nil && q.err == nil {
+		q.err = err
+	}
+}
+
+// Error reports any error from a previous Write or Flush
+func (q *quoteAllWriter) Error() error {
+	return q.err
+}
+
+// Path returns the file the CSV is written to
+func (w *CSVWriter) Path() string {
+	return w.config.FilePath
+}
+
 // WriteHeader writes the header row to the CSV file
 func (w *CSVWriter) WriteHeader() error {
 	if w.writer == nil {
@@ -102,7 +261,7 @@ func (w *CSVWriter) WriteHeader() error {
 		return nil // Header already written
 	}
 
-	err := w.writer.Write(CSVHeader)
+	err := w.writer.Write(w.columns.header())
 	if err != nil {
 		return errors.NewExternalError("failed to write CSV header", err)
 	}
@@ -119,16 +278,8 @@ func (w *CSVWriter) WriteResult(r SearchResult) error {
 		return errors.NewConfigError("CSV writer not initialized, call Initialize first", nil)
 	}
 
-	// Convert result to row format with new structure
-	row := []string{
-		r.Title,  // Título
-		r.Author, // Autor
-		r.Year,   // Ano
-		r.URL,    // Link de acesso
-	}
-
 	// Write the row
-	err := w.writer.Write(row)
+	err := w.writer.Write(w.columns.row(w.config, r))
 	if err != nil {
 		return errors.NewExternalError("failed to write CSV row", err)
 	}
@@ -143,9 +294,21 @@ func (w *CSVWriter) WriteResult(r SearchResult) error {
 	return nil
 }
 
+// csvCancelCheckInterval is how many rows are written between cancellation checks
+const csvCancelCheckInterval = 10
+
 // WriteResults writes multiple results to the CSV file
-func (w *CSVWriter) WriteResults(results []SearchResult) error {
-	for _, r := range results {
+// Cancellation is checked between batches; rows already written are flushed
+func (w *CSVWriter) WriteResults(ctx context.Context, results []SearchResult) error {
+	for i, r := range results {
+		if i%csvCancelCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				w.writer.Flush()
+				w.log.Warn("CSV export cancelled after %d rows", w.rowCount)
+				return err
+			}
+		}
+
 		if err := w.WriteResult(r); err != nil {
 			return err
 		}
@@ -158,13 +321,13 @@ func (w *CSVWriter) WriteResults(results []SearchResult) error {
 }
 
 // WriteCollection writes an entire search collection to the CSV file
-func (w *CSVWriter) WriteCollection(collection *SearchCollection) error {
+func (w *CSVWriter) WriteCollection(ctx context.Context, collection *SearchCollection) error {
 	if collection == nil {
 		return errors.NewConfigError("search collection cannot be nil", nil)
 	}
 
 	// Write all results
-	err := w.WriteResults(collection.Results)
+	err := w.WriteResults(ctx, collection.Results)
 	if err != nil {
 		return err
 	}
@@ -186,16 +349,32 @@ func WriteSummaryToCSV(collection *SearchCollection, params interface{}, outputP
 		return errors.NewConfigError("output path for summary CSV is required", nil)
 	}
 
-	// Format current date in local time
-	currentTime := collection.SearchDate.Local()
-	formattedDate := currentTime.Format("02/01/2006")
+	// The summary follows the interface language of the search
+	locale := i18n.DefaultLocale
+	configParams, hasConfig := params.(*config.SearchParams)
+	if hasConfig && i18n.IsSupported(configParams.Locale) {
+		locale = i18n.Locale(configParams.Locale)
+	}
 
-	// Determine if file exists to decide whether to write header
+	// Rows are appended to the file across runs, so they keep the header
+	// and date layout it was started with, whatever the current locale
 	fileExists := false
-	if _, err := os.Stat(outputPath); err == nil {
+	if info, err := os.Stat(outputPath); err == nil && info.Size() > 0 {
 		fileExists = true
+		fileLocale, err := summaryFileLocale(outputPath)
+		if err != nil {
+			return err
+		}
+		if fileLocale != locale && log != nil {
+			log.Info("Summary file %s is in %s, appending the row in %s", outputPath, fileLocale, fileLocale)
+		}
+		locale = fileLocale
 	}
 
+	// Format current date in local time
+	currentTime := collection.SearchDate.Local()
+	formattedDate := currentTime.Format(i18n.Message(locale, "summary.dateLayout"))
+
 	// Create directories if needed
 	dir := filepath.Dir(outputPath)
 	if dir != "" && dir != "." {
@@ -217,17 +396,24 @@ func WriteSummaryToCSV(collection *SearchCollection, params interface{}, outputP
 
 	// Write header if file is new
 	if !fileExists {
-		if err := writer.Write(SummaryCSVHeader); err != nil {
+		if err := writer.Write(SummaryCSVHeader(locale)); err != nil {
 			return errors.NewExternalError("failed to write summary CSV header", err)
 		}
 	}
 
 	// Extract filters from params if possible
 	var filtersDescription string
-	if configParams, ok := params.(*config.SearchParams); ok {
-		filtersDescription = extractFiltersDescription(configParams)
+	if hasConfig {
+		filtersDescription = extractFiltersDescription(configParams, locale)
 	} else {
-		filtersDescription = "Filtros não disponíveis"
+		filtersDescription = i18n.Message(locale, "summary.filtersUnavailable")
+	}
+
+	// An empty search is stated in words, so the row can't be mistaken for
+	// a run that failed to count its results
+	count := fmt.Sprintf("%d", collection.TotalResults)
+	if collection.TotalResults == 0 {
+		count = i18n.Message(locale, "summary.noResults")
 	}
 
 	// Create summary row
@@ -236,8 +422,9 @@ func WriteSummaryToCSV(collection *SearchCollection, params interface{}, outputP
 		"Periódicos Capes",    // Base de dados
 		collection.SearchTerm, // Termos de busca
 		formattedDate,         // Data da busca
-		fmt.Sprintf("%d", collection.TotalResults), // No de artigos encontrados
-		filtersDescription,                         // Filtros usados
+		count,                 // No de artigos encontrados
+		filtersDescription,    // Filtros usados
+		toolVersion(),         // Versão da ferramenta
 	}
 
 	// Write the summary row
@@ -252,56 +439,114 @@ func WriteSummaryToCSV(collection *SearchCollection, params interface{}, outputP
 	return nil
 }
 
-// extractFiltersDescription generates a human-readable description of the search filters in Portuguese
-func extractFiltersDescription(params *config.SearchParams) string {
+// summaryFileLocale returns the locale whose summary header the CSV file at
+// path starts with. A header of no supported locale, such as one written by
+// an older version with other columns, is an error: rows appended under it
+// would land under the wrong headings
+func summaryFileLocale(path string) (i18n.Locale, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", errors.NewConfigError(fmt.Sprintf("failed to open summary file %s", path), err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return "", errors.NewExternalError(fmt.Sprintf("failed to read the header of summary file %s", path), err)
+	}
+
+	for _, locale := range i18n.Locales() {
+		if slices.Equal(header, SummaryCSVHeader(locale)) {
+			return locale, nil
+		}
+	}
+	return "", errors.NewConfigError(
+		fmt.Sprintf("summary file %s has different columns than this version writes, not appending to it; move it aside to start a new one", path),
+		nil,
+	)
+}
+
+// toolVersion identifies the build that produced a summary row, e.g.
+// "v1.4.0 (a1b2c3d)"
+func toolVersion() string {
+	info := version.Get()
+	return fmt.Sprintf("%s (%s)", info.Version, info.Commit)
+}
+
+// extractFiltersDescription generates a human-readable description of the search filters in locale
+func extractFiltersDescription(params *config.SearchParams, locale i18n.Locale) string {
 	var filters []string
+	t := func(key string, args ...interface{}) string {
+		return i18n.Message(locale, key, args...)
+	}
 
 	// Access Type
 	if params.AccessType == "sim" {
-		filters = append(filters, "Acesso aberto: Sim")
+		filters = append(filters, t("summary.openAccess", t("summary.yes")))
 	} else if params.AccessType == "nao" {
-		filters = append(filters, "Acesso aberto: Não")
+		filters = append(filters, t("summary.openAccess", t("summary.no")))
 	}
 
 	// Publication Type
-	if params.PublicationType != "" {
-		filters = append(filters, fmt.Sprintf("Tipo de publicação: %s", params.PublicationType))
+	if len(params.PublicationTypes) > 0 {
+		filters = append(filters, t("summary.pubType", strings.Join(params.PublicationTypes, ", ")))
 	}
 
 	// Year Range
 	if params.YearMin > 0 || params.EffectiveYearMax > 0 {
-		yearStr := "Ano: "
+		var years string
 		if params.YearMin > 0 {
-			yearStr += fmt.Sprintf("%d", params.YearMin)
+			years = fmt.Sprintf("%d", params.YearMin)
 			if params.EffectiveYearMax > 0 {
-				yearStr += fmt.Sprintf(" até %d", params.EffectiveYearMax)
+				years = t("summary.yearRange", params.YearMin, params.EffectiveYearMax)
 			}
-		} else if params.EffectiveYearMax > 0 {
-			yearStr += fmt.Sprintf("Até %d", params.EffectiveYearMax)
+		} else {
+			years = t("summary.yearUntil", params.EffectiveYearMax)
 		}
-		filters = append(filters, yearStr)
+		filters = append(filters, t("summary.year", years))
 	}
 
 	// Peer Reviewed
 	if params.PeerReviewed == "sim" {
-		filters = append(filters, "Revisão por pares: Sim")
+		filters = append(filters, t("summary.peerReview", t("summary.yes")))
 	} else if params.PeerReviewed == "nao" {
-		filters = append(filters, "Revisão por pares: Não")
+		filters = append(filters, t("summary.peerReview", t("summary.no")))
 	}
 
 	// Languages
 	if len(params.Languages) > 0 {
-		langStr := "Idiomas: " + strings.Join(params.Languages, ", ")
-		filters = append(filters, langStr)
+		filters = append(filters, t("summary.languages", strings.Join(params.Languages, ", ")))
+	}
+
+	// Knowledge areas
+	if len(params.SubjectAreas) > 0 {
+		filters = append(filters, t("summary.areas", strings.Join(params.SubjectAreas, ", ")))
+	}
+
+	// Source
+	if params.Source != "" {
+		filters = append(filters, t("summary.source", params.Source))
 	}
 
 	// Max Pages
 	if params.MaxPages > 0 {
-		filters = append(filters, fmt.Sprintf("Máximo de páginas: %d", params.MaxPages))
+		filters = append(filters, t("summary.maxPages", params.MaxPages))
+	}
+
+	// Max Results
+	if params.MaxResults > 0 {
+		filters = append(filters, t("summary.maxResults", params.MaxResults))
+	}
+
+	// Incremental date
+	if params.SinceSpec != "" {
+		filters = append(filters, t("summary.since", params.SinceSpec))
 	}
 
 	if len(filters) == 0 {
-		return "Nenhum filtro aplicado"
+		return t("summary.noFilters")
 	}
 
 	return strings.Join(filters, "; ")
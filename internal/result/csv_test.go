@@ -0,0 +1,181 @@
+package result
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alexandreffaria/reviu/internal/config"
+	"github.com/alexandreffaria/reviu/internal/i18n"
+)
+
+// summaryParams returns search params for a summary in locale
+func summaryParams(locale string) *config.SearchParams {
+	params := config.NewSearchParams()
+	params.SearchTerm = "soil"
+	params.Locale = locale
+	return params
+}
+
+func TestWriteSummaryToCSVKeepsFileLocale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results_summary.csv")
+	collection := NewSearchCollection("soil")
+	collection.SearchDate = time.Date(2024, 3, 5, 12, 0, 0, 0, time.Local)
+	collection.TotalResults = 12
+
+	if err := WriteSummaryToCSV(collection, summaryParams("pt"), path, quietLogger()); err != nil {
+		t.Fatalf("first WriteSummaryToCSV() error = %v", err)
+	}
+	// A later run with the English interface appends to the same file
+	if err := WriteSummaryToCSV(collection, summaryParams("en"), path, quietLogger()); err != nil {
+		t.Fatalf("second WriteSummaryToCSV() error = %v", err)
+	}
+
+	records := readCSV(t, path)
+	if len(records) != 3 {
+		t.Fatalf("summary = %v, want one header and two rows", records)
+	}
+	if header := records[0]; header[0] != i18n.Message(i18n.LocalePT, "summary.responsible") {
+		t.Errorf("header = %v, want the Portuguese header kept", header)
+	}
+	for i, row := range records[1:] {
+		if row[3] != "05/03/2024" {
+			t.Errorf("row %d date = %q, want the file's layout 05/03/2024", i+1, row[3])
+		}
+		if row[4] != "12" {
+			t.Errorf("row %d count = %q, want 12", i+1, row[4])
+		}
+	}
+}
+
+func TestWriteSummaryToCSVNewFileFollowsLocale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results_summary.csv")
+	collection := NewSearchCollection("soil")
+	collection.SearchDate = time.Date(2024, 3, 5, 12, 0, 0, 0, time.Local)
+
+	if err := WriteSummaryToCSV(collection, summaryParams("en"), path, quietLogger()); err != nil {
+		t.Fatalf("WriteSummaryToCSV() error = %v", err)
+	}
+
+	records := readCSV(t, path)
+	if header := records[0]; header[0] != "Responsible" {
+		t.Errorf("header = %v, want the English header", header)
+	}
+	if row := records[1]; row[3] != "2024-03-05" || row[4] != "0 results" {
+		t.Errorf("row = %v, want the English date layout and count", row)
+	}
+}
+
+func TestWriteSummaryToCSVRefusesUnknownHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results_summary.csv")
+	old := "Responsável,Base de dados,Termos de busca,Data da busca,No de artigos encontrados,Filtros usados\n"
+	if err := os.WriteFile(path, []byte(old), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteSummaryToCSV(NewSearchCollection("soil"), summaryParams("pt"), path, quietLogger()); err == nil {
+		t.Fatal("WriteSummaryToCSV() appended under a header with other columns")
+	}
+	if data, _ := os.ReadFile(path); string(data) != old {
+		t.Errorf("summary file changed to %q", data)
+	}
+}
+
+// cancelAfterChecks is a context cancelled once Err has been checked checks times
+type cancelAfterChecks struct {
+	context.Context
+	checks int
+}
+
+func (c *cancelAfterChecks) Err() error {
+	if c.checks == 0 {
+		return context.Canceled
+	}
+	c.checks--
+	return nil
+}
+
+func TestCSVWriterCancelMidWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.csv")
+	writer, err := NewCSVWriter(ExportConfig{FilePath: path, Format: FormatCSV, IncludeHeader: true}, quietLogger())
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+	if err := writer.Initialize(); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	results := make([]SearchResult, 3*csvCancelCheckInterval)
+	for i := range results {
+		results[i] = SearchResult{ID: fmt.Sprintf("W%d", i+1), Title: "Soil"}
+	}
+
+	// Cancelled after the second batch has been written
+	ctx := &cancelAfterChecks{Context: context.Background(), checks: 2}
+	if err := writer.WriteResults(ctx, results); err != context.Canceled {
+		t.Fatalf("WriteResults() = %v, want context.Canceled", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() after cancelling = %v, want nil", err)
+	}
+
+	records := readCSV(t, path)
+	if rows := len(records) - 1; rows != 2*csvCancelCheckInterval {
+		t.Errorf("wrote %d rows, want the %d before cancelling", rows, 2*csvCancelCheckInterval)
+	}
+}
+
+func TestCSVWriterAuthorSeparator(t *testing.T) {
+	authors := []string{"Silva, João", "Souza, Maria"}
+
+	tests := []struct {
+		separator string
+		want      string
+	}{
+		{"", "Silva, João; Souza, Maria"},
+		{" | ", "Silva, João | Souza, Maria"},
+	}
+
+	for _, tt := range tests {
+		result := SearchResult{ID: "W1", Title: "Soil", Authors: authors}
+		path := filepath.Join(t.TempDir(), "results.csv")
+		writer, err := NewCSVWriter(ExportConfig{
+			FilePath:        path,
+			Format:          FormatCSV,
+			IncludeHeader:   true,
+			AuthorSeparator: tt.separator,
+		}, quietLogger())
+		if err != nil {
+			t.Fatalf("NewCSVWriter() error = %v", err)
+		}
+		if err := writer.Initialize(); err != nil {
+			t.Fatalf("Initialize() error = %v", err)
+		}
+		if err := writer.WriteResult(result); err != nil {
+			t.Fatalf("WriteResult() error = %v", err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+
+		records := readCSV(t, path)
+		column := -1
+		for i, name := range records[0] {
+			if name == "Autor" {
+				column = i
+			}
+		}
+		if column < 0 || len(records) != 2 {
+			t.Fatalf("export = %v, want a header with Autor and one row", records)
+		}
+		if got := records[1][column]; got != tt.want {
+			t.Errorf("separator %q: author cell = %q, want %q", tt.separator, got, tt.want)
+		}
+		if len(result.Authors) != 2 || result.Authors[0] != "Silva, João" {
+			t.Errorf("separator %q: authors changed to %q", tt.separator, result.Authors)
+		}
+	}
+}
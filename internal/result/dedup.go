@@ -0,0 +1,115 @@
+package result
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/alexandreffaria/reviu/internal/textnorm"
+)
+
+// MinFuzzyTitleLength is the minimum normalized title length for fuzzy
+// matching. Shorter titles (e.g. "Editorial", "Introduction") are only
+// merged when they match exactly, since a few edits can turn one into another
+const MinFuzzyTitleLength = 20
+
+// DeduplicateFuzzyTitles removes results whose normalized titles are within
+// maxDistance edits of an earlier result from the same year, keeping the
+// first occurrence. It returns the number of results removed
+func (c *SearchCollection) DeduplicateFuzzyTitles(maxDistance int) int {
+	if maxDistance <= 0 || len(c.Results) < 2 {
+		return 0
+	}
+
+	type keptTitle struct {
+		year  string
+		title []rune
+	}
+
+	var kept []keptTitle
+	unique := make([]SearchResult, 0, len(c.Results))
+
+	for _, result := range c.Results {
+		title := []rune(normalizeTitle(result.Title))
+		duplicate := false
+
+		for _, other := range kept {
+			if other.year != result.Year {
+				continue
+			}
+			if titlesMatch(title, other.title, maxDistance) {
+				duplicate = true
+				break
+			}
+		}
+
+		if !duplicate {
+			kept = append(kept, keptTitle{year: result.Year, title: title})
+			unique = append(unique, result)
+		}
+	}
+
+	removed := len(c.Results) - len(unique)
+	c.Results = unique
+	c.TotalResults = len(c.Results)
+	return removed
+}
+
+// titlesMatch reports whether two normalized titles are the same publication
+func titlesMatch(a, b []rune, maxDistance int) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+
+	if string(a) == string(b) {
+		return true
+	}
+
+	// Short titles must match exactly
+	if len(a) < MinFuzzyTitleLength || len(b) < MinFuzzyTitleLength {
+		return false
+	}
+
+	// The length difference is a lower bound on the distance
+	if diff := len(a) - len(b); diff > maxDistance || -diff > maxDistance {
+		return false
+	}
+
+	return levenshtein(a, b) <= maxDistance
+}
+
+// normalizeTitle lowercases a title and reduces accents, punctuation and
+// spacing so cosmetic differences don't affect comparisons
+func normalizeTitle(title string) string {
+	mapped := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, textnorm.StripAccents(title))
+
+	return textnorm.Normalize(mapped)
+}
+
+// levenshtein computes the edit distance between two rune slices
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
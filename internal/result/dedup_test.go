@@ -0,0 +1,125 @@
+package result
+
+import "testing"
+
+// resultTitles returns the titles of results, in order
+func resultTitles(results []SearchResult) []string {
+	titles := make([]string, len(results))
+	for i, result := range results {
+		titles[i] = result.Title
+	}
+	return titles
+}
+
+func TestDeduplicateFuzzyTitles(t *testing.T) {
+	tests := []struct {
+		name   string
+		titles [][2]string // Title and year of each result
+		want   []string
+	}{
+		{
+			name: "case and punctuation",
+			titles: [][2]string{
+				{"Soil carbon dynamics in tropical forests.", "2021"},
+				{"SOIL CARBON DYNAMICS IN TROPICAL FORESTS", "2021"},
+			},
+			want: []string{"Soil carbon dynamics in tropical forests."},
+		},
+		{
+			name: "accents and a typo",
+			titles: [][2]string{
+				{"Efeito da adubação nitrogenada no milho", "2020"},
+				{"Efeito da adubacao nitrogenada no milh", "2020"},
+			},
+			want: []string{"Efeito da adubação nitrogenada no milho"},
+		},
+		{
+			name: "near-duplicate from another year",
+			titles: [][2]string{
+				{"Soil carbon dynamics in tropical forests", "2021"},
+				{"Soil carbon dynamics in tropical forest", "2019"},
+			},
+			want: []string{"Soil carbon dynamics in tropical forests", "Soil carbon dynamics in tropical forest"},
+		},
+		{
+			name: "different titles beyond the distance",
+			titles: [][2]string{
+				{"Soil carbon dynamics in tropical forests", "2021"},
+				{"Soil nitrogen dynamics in boreal forests", "2021"},
+			},
+			want: []string{"Soil carbon dynamics in tropical forests", "Soil nitrogen dynamics in boreal forests"},
+		},
+		{
+			name: "distinct short titles",
+			titles: [][2]string{
+				{"Editorial", "2021"},
+				{"Editorials", "2021"},
+				{"Soil I", "2021"},
+				{"Soil II", "2021"},
+			},
+			want: []string{"Editorial", "Editorials", "Soil I", "Soil II"},
+		},
+		{
+			name: "identical short titles",
+			titles: [][2]string{
+				{"Editorial", "2021"},
+				{"Editorial.", "2021"},
+			},
+			want: []string{"Editorial"},
+		},
+	}
+
+	for _, tt := range tests {
+		collection := NewSearchCollection("soil")
+		for _, title := range tt.titles {
+			collection.Results = append(collection.Results, SearchResult{Title: title[0], Year: title[1]})
+		}
+		collection.TotalResults = len(collection.Results)
+
+		removed := collection.DeduplicateFuzzyTitles(2)
+		got := resultTitles(collection.Results)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: kept %q, want %q", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: kept %q, want %q", tt.name, got, tt.want)
+				break
+			}
+		}
+		if removed != len(tt.titles)-len(tt.want) || collection.TotalResults != len(tt.want) {
+			t.Errorf("%s: removed %d leaving %d, want %d leaving %d",
+				tt.name, removed, collection.TotalResults, len(tt.titles)-len(tt.want), len(tt.want))
+		}
+	}
+}
+
+func TestDeduplicateFuzzyTitlesOff(t *testing.T) {
+	collection := NewSearchCollection("soil")
+	collection.Results = []SearchResult{
+		{Title: "Soil carbon dynamics in tropical forests", Year: "2021"},
+		{Title: "Soil carbon dynamics in tropical forest", Year: "2021"},
+	}
+
+	if removed := collection.DeduplicateFuzzyTitles(0); removed != 0 || len(collection.Results) != 2 {
+		t.Errorf("DeduplicateFuzzyTitles(0) removed %d, want fuzzy matching off", removed)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"soil", "", 4},
+		{"kitten", "sitting", 3},
+		{"adubação", "adubacao", 2},
+	}
+	for _, tt := range tests {
+		if got := levenshtein([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
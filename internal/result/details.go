@@ -0,0 +1,169 @@
+package result
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/alexandreffaria/reviu/internal/browser"
+	"github.com/alexandreffaria/reviu/internal/errors"
+)
+
+// detailWorker owns a browser that stays open across detail pages and
+// results pages, so each publication only costs a navigation
+type detailWorker struct {
+	browser browser.Browser
+	opened  bool
+}
+
+// open shows url in the worker browser, launching it on first use
+func (w *detailWorker) open(ctx context.Context, url string) error {
+	if w.opened {
+		return w.browser.Navigate(ctx, url)
+	}
+
+	if err := w.browser.Open(ctx, url); err != nil {
+		// Start from a clean browser on the next attempt
+		w.browser.Close()
+		return err
+	}
+	w.opened = true
+	return nil
+}
+
+// workers returns n detail workers, creating the missing ones
+// Workers are kept between pages and closed when Process returns
+func (e *CAPESResultExtractor) workers(n int) []*detailWorker {
+	for len(e.detailWorkers) < n {
+		e.detailWorkers = append(e.detailWorkers, &detailWorker{browser: e.newDetailBrowser()})
+	}
+	return e.detailWorkers[:n]
+}
+
+// closeDetailWorkers closes the browsers of all detail workers
+func (e *CAPESResultExtractor) closeDetailWorkers() {
+	for _, w := range e.detailWorkers {
+		if err := w.browser.Close(); err != nil {
+			e.log.Warn("Error closing detail browser: %v", err)
+		}
+	}
+	e.detailWorkers = nil
+}
+
+// extractDetailsConcurrently fills in the metadata of results using up to
+// DetailConcurrency workers, each with its own browser
+//
+// Results keep their position in the slice, so the returned results are in
+// their original order. A failing detail page is recorded and leaves its
+// result with only the data from the results page; only a network error,
+// which would hit every remaining page as well, stops the batch. When ctx is
+// done, no new pages are opened and the in-flight ones are left to finish.
+func (e *CAPESResultExtractor) extractDetailsConcurrently(ctx context.Context, results []SearchResult) ([]SearchResult, error) {
+	if len(results) == 0 {
+		return results, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		jobs     = make(chan int)
+		done     = make([]bool, len(results))
+		wg       sync.WaitGroup
+		stopOnce sync.Once
+		stopErr  error
+	)
+
+	for _, w := range e.workers(min(e.options.DetailConcurrency, len(results))) {
+		wg.Add(1)
+		go func(w *detailWorker) {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					continue // Drain the remaining jobs
+				}
+
+				if err := e.extractDetailsWith(ctx, w, &results[i]); err != nil {
+					stopOnce.Do(func() {
+						stopErr = err
+						cancel()
+					})
+					continue
+				}
+				done[i] = ctx.Err() == nil
+			}
+		}(w)
+	}
+
+dispatch:
+	for i := range results {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	// Keep only the results whose details were extracted, in their original order
+	extracted := make([]SearchResult, 0, len(results))
+	for i, result := range results {
+		if done[i] {
+			extracted = append(extracted, result)
+		}
+	}
+
+	if stopErr != nil {
+		return extracted, stopErr
+	}
+	return extracted, ctx.Err()
+}
+
+// extractDetailsWith opens the detail page of result in the worker browser
+// and fills in its metadata
+// Like extractMetadataForResult, only network errors are returned
+func (e *CAPESResultExtractor) extractDetailsWith(ctx context.Context, w *detailWorker, result *SearchResult) (err error) {
+	detailURL := result.URL
+	if detailURL == "" {
+		return nil
+	}
+
+	// A panic on one page must not take down the other workers
+	defer func() {
+		if r := recover(); r != nil {
+			e.log.Error("Recovered from panic on details page %s: %v", detailURL, r)
+			e.recordError(detailURL, "details", fmt.Errorf("panic: %v", r))
+			err = nil
+		}
+	}()
+
+	// The limiter is shared by all workers, bounding their combined rate
+	if e.limiter.Wait(ctx) != nil {
+		return nil // Cancelled; the result is left out as not done
+	}
+
+	timeout := e.pageTimeout()
+	err = e.withRetry(ctx, "load details page "+detailURL, func() error {
+		if err := w.open(ctx, detailURL); err != nil {
+			return err
+		}
+		return e.waitForDetails(ctx, w.browser, timeout)
+	})
+
+	// Don't spend time extracting a page nobody is waiting for
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	if err != nil {
+		e.abandonDetails(detailURL, err)
+		if errors.IsErrorType(err, errors.Network) {
+			return err
+		}
+		return nil
+	}
+
+	e.extractDetailFields(ctx, w.browser, result)
+	return nil
+}
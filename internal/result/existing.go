@@ -0,0 +1,150 @@
+package result
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alexandreffaria/reviu/internal/errors"
+)
+
+// Column names used to recognize already-exported results
+const (
+	linkColumn = "Link de acesso"
+	doiColumn  = "DOI"
+)
+
+// ExistingKeys holds the identifiers of results already present in an export,
+// so appended runs can skip the same publication found again
+type ExistingKeys struct {
+	IDs  map[string]bool
+	DOIs map[string]bool
+}
+
+// NewExistingKeys creates an empty key set
+func NewExistingKeys() *ExistingKeys {
+	return &ExistingKeys{
+		IDs:  make(map[string]bool),
+		DOIs: make(map[string]bool),
+	}
+}
+
+// AddCollection records the IDs and DOIs of the results in collection
+func (k *ExistingKeys) AddCollection(collection *SearchCollection) {
+	for id := range collection.IndexByID() {
+		k.IDs[id] = true
+	}
+	for doi := range collection.IndexByDOI() {
+		k.DOIs[doi] = true
+	}
+}
+
+// Merge adds the identifiers of other to k
+func (k *ExistingKeys) Merge(other *ExistingKeys) {
+	for id := range other.IDs {
+		k.IDs[id] = true
+	}
+	for doi := range other.DOIs {
+		k.DOIs[doi] = true
+	}
+}
+
+// Contains reports whether result matches a known ID or DOI
+// The same paper surfaced by different searches may carry different CAPES IDs,
+// so the DOI is checked as well
+func (k *ExistingKeys) Contains(result SearchResult) bool {
+	if result.ID != "" && k.IDs[result.ID] {
+		return true
+	}
+	doi := NormalizeDOI(result.DOI)
+	return doi != "" && k.DOIs[doi]
+}
+
+// Len returns the number of distinct identifiers recorded
+func (k *ExistingKeys) Len() int {
+	return len(k.IDs) + len(k.DOIs)
+}
+
+// ReadExistingKeys collects the IDs and DOIs of the rows in an existing CSV
+// export. IDs are recovered from the access link column; DOIs are read from the
+// DOI column when the file has one. A missing file yields an empty set
+func ReadExistingKeys(filePath string, delimiter rune) (*ExistingKeys, error) {
+	keys := NewExistingKeys()
+
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return keys, nil
+	}
+	if err != nil {
+		return nil, errors.NewConfigError(fmt.Sprintf("failed to open existing export %s", filePath), err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	if delimiter != 0 {
+		reader.Comma = delimiter
+	}
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return keys, nil
+	}
+	if err != nil {
+		return nil, errors.NewExternalError(fmt.Sprintf("failed to read header of %s", filePath), err)
+	}
+
+	linkIndex, doiIndex := -1, -1
+	for i, column := range header {
+		switch strings.TrimPrefix(strings.TrimSpace(column), "\ufeff") {
+		case linkColumn:
+			linkIndex = i
+		case doiColumn:
+			doiIndex = i
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.NewExternalError(fmt.Sprintf("failed to read %s", filePath), err)
+		}
+
+		if linkIndex >= 0 && linkIndex < len(record) {
+			if id := extractIDFromURL(record[linkIndex]); id != "" {
+				keys.IDs[id] = true
+			}
+		}
+		if doiIndex >= 0 && doiIndex < len(record) {
+			if doi := NormalizeDOI(record[doiIndex]); doi != "" {
+				keys.DOIs[doi] = true
+			}
+		}
+	}
+
+	return keys, nil
+}
+
+// NormalizeDOI reduces a DOI to its bare, lowercase "10.xxxx/..." form
+// DOIs are case-insensitive, so lowercasing makes comparisons reliable
+func NormalizeDOI(doi string) string {
+	return strings.ToLower(BareDOI(doi))
+}
+
+// BareDOI strips a resolver URL or "doi:" prefix from doi, keeping the
+// "10.xxxx/..." form as written
+func BareDOI(doi string) string {
+	doi = strings.TrimSpace(doi)
+	lower := strings.ToLower(doi)
+	for _, prefix := range []string{"https://doi.org/", "http://doi.org/", "https://dx.doi.org/", "http://dx.doi.org/", "doi:"} {
+		if strings.HasPrefix(lower, prefix) {
+			return strings.TrimSpace(doi[len(prefix):])
+		}
+	}
+	return doi
+}
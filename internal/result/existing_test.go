@@ -0,0 +1,107 @@
+package result
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexandreffaria/reviu/internal/browser/browsertest"
+	"github.com/alexandreffaria/reviu/internal/config"
+)
+
+func TestNormalizeDOI(t *testing.T) {
+	tests := []struct {
+		doi  string
+		want string
+	}{
+		{"10.1000/abc", "10.1000/abc"},
+		{"10.1000/ABC.Def", "10.1000/abc.def"},
+		{"https://doi.org/10.1000/ABC", "10.1000/abc"},
+		{"http://dx.doi.org/10.1000/abc", "10.1000/abc"},
+		{"HTTPS://DOI.ORG/10.1000/abc", "10.1000/abc"},
+		{"doi:10.1000/abc", "10.1000/abc"},
+		{"DOI: 10.1000/abc ", "10.1000/abc"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeDOI(tt.doi); got != tt.want {
+			t.Errorf("NormalizeDOI(%q) = %q, want %q", tt.doi, got, tt.want)
+		}
+	}
+}
+
+func TestReadExistingKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.csv")
+	data := utf8BOM + "Título,Link de acesso,DOI\n" +
+		"A," + detailURL("W1") + ",https://doi.org/10.1000/ABC\n" +
+		"B," + detailURL("W2") + ",\n" +
+		"C,,doi:10.1000/xyz\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := ReadExistingKeys(path, 0)
+	if err != nil {
+		t.Fatalf("ReadExistingKeys() error = %v", err)
+	}
+	if len(keys.IDs) != 2 || !keys.IDs["W1"] || !keys.IDs["W2"] {
+		t.Errorf("IDs = %v, want W1 and W2", keys.IDs)
+	}
+	if len(keys.DOIs) != 2 || !keys.DOIs["10.1000/abc"] || !keys.DOIs["10.1000/xyz"] {
+		t.Errorf("DOIs = %v, want the two DOIs normalized", keys.DOIs)
+	}
+
+	tests := []struct {
+		result SearchResult
+		want   bool
+	}{
+		{SearchResult{ID: "W1"}, true},
+		{SearchResult{ID: "W7", DOI: "10.1000/Abc"}, true},
+		{SearchResult{ID: "W8", DOI: "https://doi.org/10.1000/XYZ"}, true},
+		{SearchResult{ID: "W9", DOI: "10.1000/other"}, false},
+		{SearchResult{}, false},
+	}
+	for _, tt := range tests {
+		if got := keys.Contains(tt.result); got != tt.want {
+			t.Errorf("Contains(ID %q, DOI %q) = %v, want %v", tt.result.ID, tt.result.DOI, got, tt.want)
+		}
+	}
+
+	missing, err := ReadExistingKeys(filepath.Join(t.TempDir(), "missing.csv"), 0)
+	if err != nil || missing.Len() != 0 {
+		t.Errorf("ReadExistingKeys(missing) = %v, %v; want an empty set", missing, err)
+	}
+}
+
+func TestProcessAndExportAppendSkipsExistingDOI(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "results.csv")
+	prior := NewSearchCollection("soil")
+	prior.AddResult(SearchResult{ID: "W9", Title: "Exported before", URL: detailURL("W9"), DOI: "https://doi.org/10.1000/ABC"})
+	exportCSV(t, output, false, prior.Results)
+
+	// A new search finds the same paper under another CAPES ID
+	fake := browsertest.NewFakeBrowser()
+	scriptSearch(fake, 2, []int{2})
+	fake.SetText(detailURL("W1-1"), DetailDOISelector, "doi:10.1000/abc")
+	fake.SetText(detailURL("W1-2"), DetailDOISelector, "10.1000/new")
+
+	params := config.NewSearchParams()
+	params.SearchTerm = "soil"
+	params.OutputFile = output
+	params.Append = true
+
+	processor := NewResultProcessor(fake, quietLogger())
+	processor.SetOptions(testOptions())
+	if err := processor.ProcessAndExport(context.Background(), params, testSearchURL); err != nil {
+		t.Fatalf("ProcessAndExport() error = %v", err)
+	}
+
+	records := readCSV(t, output)
+	if len(records) != 3 {
+		t.Fatalf("export = %v, want the header, the prior row and one new row", records)
+	}
+	if records[1][0] != "Exported before" || records[2][0] != "Title W1-2" {
+		t.Errorf("rows = %q and %q, want the prior row then only the new DOI", records[1][0], records[2][0])
+	}
+}
@@ -1,9 +1,16 @@
 package result
 
 import (
+	"compress/gzip"
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"strings"
+	"time"
 	
+	"github.com/alexandreffaria/reviu/internal/errors"
 	"github.com/alexandreffaria/reviu/internal/logger"
 )
 
@@ -11,11 +18,29 @@ import (
 type ExportFormat string
 
 const (
-	FormatCSV  ExportFormat = "csv"
-	FormatJSON ExportFormat = "json"
-	FormatText ExportFormat = "txt"
+	FormatCSV      ExportFormat = "csv"
+	FormatJSON     ExportFormat = "json"
+	FormatText     ExportFormat = "txt"
+	FormatBibTeX   ExportFormat = "bibtex"
+	FormatXLSX     ExportFormat = "xlsx"
+	FormatMarkdown ExportFormat = "markdown"
+	FormatHTML     ExportFormat = "html"
+	FormatRIS      ExportFormat = "ris"
+	FormatNDJSON   ExportFormat = "ndjson"
 )
 
+// Extension returns the file extension used for the format, without the dot
+func (f ExportFormat) Extension() string {
+	switch f {
+	case FormatBibTeX:
+		return "bib"
+	case FormatMarkdown:
+		return "md"
+	default:
+		return string(f)
+	}
+}
+
 // ExportConfig holds configuration for the export process
 type ExportConfig struct {
 	// File path for export
@@ -27,11 +52,58 @@ type ExportConfig struct {
 	// CSV-specific options
 	Delimiter   rune   // Character to use as delimiter in CSV
 	IncludeHeader bool  // Whether to include header row in CSV
+	QuoteAll    bool   // Quote every CSV field, not only those that need it
 	
 	// Encoding options
 	CharacterEncoding string // e.g., "utf-8", "iso-8859-1"
+	
+	// Compress gzips the output and appends ".gz" to the file name
+	Compress bool
+	
+	// OnExisting decides what happens when the output file already exists
+	OnExisting ExistingFileMode
+	
+	// AuthorSeparator joins multiple authors in a single cell (default "; ")
+	AuthorSeparator string
+	
+	// Columns chooses the columns of tabular exports and their order, by
+	// name (see ColumnNames); empty means DefaultColumns
+	Columns []string
+	
+	// WriteBOM starts CSV files with a UTF-8 byte order mark, which Excel
+	// needs to show accented characters correctly
+	WriteBOM bool
+	
+	// PublicationTypes are the types the search was filtered by, if any;
+	// BibTeX uses them to pick the entry type
+	PublicationTypes []string
+	
+	// IncludeProvenance adds a "Fontes" column listing the searches that
+	// surfaced each result, for merged master exports
+	IncludeProvenance bool
+	
+	// Append adds the rows to an existing, non-empty CSV instead of replacing
+	// it, without repeating the header; OnExisting then doesn't apply
+	Append bool
 }
 
+// DefaultAuthorSeparator joins authors in exported cells; unlike a comma it
+// doesn't collide with the CSV delimiter or with "Surname, Given" names
+const DefaultAuthorSeparator = "; "
+
+// ExistingFileMode defines how an export treats an output file that already exists
+type ExistingFileMode string
+
+const (
+	ExistingOverwrite ExistingFileMode = "overwrite" // Replace the file (with a warning)
+	ExistingFail      ExistingFileMode = "fail"      // Refuse to export
+	ExistingBackup    ExistingFileMode = "backup"    // Rename the old file to <name>.bak first
+	ExistingTimestamp ExistingFileMode = "timestamp" // Write to <name>.<timestamp>.<ext> instead
+)
+
+// existingTimestampLayout formats the timestamp inserted by ExistingTimestamp
+const existingTimestampLayout = "20060102-150405"
+
 // DefaultCSVConfig returns a default configuration for CSV export
 func DefaultCSVConfig(filePath string) ExportConfig {
 	return ExportConfig{
@@ -40,26 +112,42 @@ func DefaultCSVConfig(filePath string) ExportConfig {
 		Delimiter:         ',',
 		IncludeHeader:     true,
 		CharacterEncoding: "utf-8",
+		AuthorSeparator:   DefaultAuthorSeparator,
 	}
 }
 
 // ExportStats captures statistics about an export operation
 type ExportStats struct {
-	StartTime       string
-	EndTime         string
-	Duration        string
+	StartTime       time.Time
+	EndTime         time.Time
+	Duration        time.Duration
 	TotalResults    int
 	ResultsWritten  int
-	BytesWritten    int64
-	ErrorCount      int
+	BytesWritten    int64 // Size of the export files on disk
+	ErrorCount      int   // Results that failed to extract plus writer errors
 	FilePath        string
+	Files           []ExportedFile // Every export file, one per format
+}
+
+// ExportedFile is a file written by an export and its size on disk
+type ExportedFile struct {
+	Path  string
+	Bytes int64
+}
+
+// ResultsPerMinute returns the export throughput, or 0 for an instant run
+func (s *ExportStats) ResultsPerMinute() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+	return float64(s.ResultsWritten) / s.Duration.Minutes()
 }
 
 // String returns a formatted string with export statistics
 func (s *ExportStats) String() string {
 	return fmt.Sprintf(
 		"Export completed in %s. Wrote %d/%d results (%d bytes) to %s with %d errors.",
-		s.Duration,
+		s.Duration.Round(time.Second),
 		s.ResultsWritten,
 		s.TotalResults,
 		s.BytesWritten,
@@ -79,24 +167,39 @@ type ResultWriter interface {
 	// WriteResult writes a single result
 	WriteResult(result SearchResult) error
 	
-	// WriteResults writes multiple results
-	WriteResults(results []SearchResult) error
+	// WriteResults writes multiple results, stopping early if ctx is cancelled
+	WriteResults(ctx context.Context, results []SearchResult) error
 	
-	// WriteCollection writes an entire search collection
-	WriteCollection(collection *SearchCollection) error
+	// WriteCollection writes an entire search collection, stopping early if ctx is cancelled
+	WriteCollection(ctx context.Context, collection *SearchCollection) error
 	
 	// Close finalizes the export and releases resources
 	Close() error
+	
+	// Path returns the file the writer writes to, known after Initialize
+	Path() string
 }
 
 // NewWriter creates the appropriate ResultWriter based on export config
 func NewWriter(config ExportConfig, log logger.Logger) (ResultWriter, error) {
 	// Ensure the file extension matches the format
-	config.FilePath = ensureExtension(config.FilePath, string(config.Format))
+	config.FilePath = ExportFilePath(config)
 
 	switch config.Format {
 	case FormatCSV:
 		return NewCSVWriter(config, log)
+	case FormatBibTeX:
+		return NewBibTeXWriter(config, log)
+	case FormatXLSX:
+		return NewXLSXWriter(config, log)
+	case FormatMarkdown:
+		return NewMarkdownWriter(config, log)
+	case FormatHTML:
+		return NewHTMLWriter(config, log)
+	case FormatRIS:
+		return NewRISWriter(config, log)
+	case FormatNDJSON:
+		return NewNDJSONWriter(config, log)
 	case FormatJSON, FormatText:
 		// Placeholder for future implementation
 		return nil, fmt.Errorf("format %s not yet implemented", config.Format)
@@ -105,6 +208,121 @@ func NewWriter(config ExportConfig, log logger.Logger) (ResultWriter, error) {
 	}
 }
 
+// ExportFilePath returns the path the export will actually be written to,
+// with the extension of the format and ".gz" when compression is enabled
+func ExportFilePath(config ExportConfig) string {
+	filePath := ensureExtension(strings.TrimSuffix(config.FilePath, gzipExtension), config.Format.Extension())
+	if config.Compress {
+		filePath += gzipExtension
+	}
+	return filePath
+}
+
+// resolveOutputPath applies mode to filePath when the file already exists and
+// returns the path the export should be written to
+func resolveOutputPath(filePath string, mode ExistingFileMode, now time.Time, log logger.Logger) (string, error) {
+	if _, err := os.Stat(filePath); err != nil {
+		return filePath, nil // Nothing to protect
+	}
+
+	switch mode {
+	case ExistingFail:
+		return "", errors.WithCode(errors.NewConfigError(
+			fmt.Sprintf("output file %s already exists (use -on-existing overwrite, backup or timestamp)", filePath),
+			nil,
+		), errors.CodeOutputExists)
+
+	case ExistingBackup:
+		backupPath := filePath + ".bak"
+		os.Remove(backupPath) // Rename can't replace files on Windows
+		if err := os.Rename(filePath, backupPath); err != nil {
+			return "", errors.NewExternalError(fmt.Sprintf("failed to back up %s", filePath), err)
+		}
+		log.Info("Existing output file backed up to %s", backupPath)
+		return filePath, nil
+
+	case ExistingTimestamp:
+		stampedPath := insertBeforeExtension(filePath, "."+now.Format(existingTimestampLayout))
+		log.Info("Output file %s already exists, writing to %s", filePath, stampedPath)
+		return stampedPath, nil
+
+	default:
+		log.Warn("Overwriting existing output file %s", filePath)
+		return filePath, nil
+	}
+}
+
+// insertBeforeExtension inserts suffix before the file extension, keeping a
+// trailing ".gz" together with the extension it compresses
+func insertBeforeExtension(filePath, suffix string) string {
+	base := strings.TrimSuffix(filePath, gzipExtension)
+	compressed := base != filePath
+
+	ext := filepath.Ext(base)
+	result := strings.TrimSuffix(base, ext) + suffix + ext
+	if compressed {
+		result += gzipExtension
+	}
+	return result
+}
+
+// gzipExtension is appended to the names of compressed exports
+const gzipExtension = ".gz"
+
+// gzipFile closes the gzip stream before the file underneath it, so the
+// gzip footer is flushed to disk
+type gzipFile struct {
+	*gzip.Writer
+	file *os.File
+}
+
+// Close finishes the gzip stream and then closes the file
+func (g *gzipFile) Close() error {
+	if err := g.Writer.Close(); err != nil {
+		g.file.Close()
+		return err
+	}
+	return g.file.Close()
+}
+
+// openExportFile prepares the output of an export: it creates missing
+// directories, applies the OnExisting mode (updating config.FilePath when the
+// file is redirected) and opens the file, compressed if configured
+func openExportFile(config *ExportConfig, log logger.Logger) (io.WriteCloser, error) {
+	dir := filepath.Dir(config.FilePath)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, errors.NewConfigError(fmt.Sprintf("failed to create directory %s", dir), err)
+		}
+	}
+
+	filePath, err := resolveOutputPath(config.FilePath, config.OnExisting, time.Now(), log)
+	if err != nil {
+		return nil, err
+	}
+	config.FilePath = filePath
+
+	file, err := createOutputFile(config.FilePath, config.Compress)
+	if err != nil {
+		return nil, errors.NewConfigError(fmt.Sprintf("failed to create file %s", config.FilePath), err)
+	}
+	return file, nil
+}
+
+// createOutputFile creates the export file, wrapping it in a gzip writer
+// when compress is set
+func createOutputFile(filePath string, compress bool) (io.WriteCloser, error) {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !compress {
+		return file, nil
+	}
+	return &gzipFile{Writer: gzip.NewWriter(file), file: file}, nil
+}
+
 // ensureExtension ensures the filepath has the correct extension
 func ensureExtension(filePath, ext string) string {
 	currentExt := filepath.Ext(filePath)
@@ -118,4 +336,25 @@ func ensureExtension(filePath, ext string) string {
 	}
 	
 	return filePath
-}
\ No newline at end of file
+}
+
+// WriteURLList writes one URL per line to the given file
+func WriteURLList(filePath string, urls []string) error {
+	if filePath == "" {
+		return errors.NewConfigError("file path is required for URL list", nil)
+	}
+
+	dir := filepath.Dir(filePath)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return errors.NewConfigError(fmt.Sprintf("failed to create directory %s", dir), err)
+		}
+	}
+
+	content := strings.Join(urls, "\n") + "\n"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		return errors.NewExternalError(fmt.Sprintf("failed to write URL list %s", filePath), err)
+	}
+
+	return nil
+}
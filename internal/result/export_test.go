@@ -0,0 +1,142 @@
+package result
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alexandreffaria/reviu/internal/errors"
+)
+
+// existingFile creates dir/name holding "old" and returns its path
+func existingFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// fileContent returns the content of path, or "" when it doesn't exist
+func fileContent(path string) string {
+	data, _ := os.ReadFile(path)
+	return string(data)
+}
+
+func TestResolveOutputPathModes(t *testing.T) {
+	now := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+
+	t.Run("overwrite", func(t *testing.T) {
+		path := existingFile(t, t.TempDir(), "out.csv")
+		got, err := resolveOutputPath(path, ExistingOverwrite, now, quietLogger())
+		if err != nil || got != path {
+			t.Errorf("resolveOutputPath() = %q, %v; want %q", got, err, path)
+		}
+		if fileContent(path) != "old" {
+			t.Error("overwrite mode changed the file before the export")
+		}
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		path := existingFile(t, t.TempDir(), "out.csv")
+		_, err := resolveOutputPath(path, ExistingFail, now, quietLogger())
+		if errors.Code(err) != errors.CodeOutputExists {
+			t.Errorf("resolveOutputPath() = %v, want code %s", err, errors.CodeOutputExists)
+		}
+		if fileContent(path) != "old" {
+			t.Error("fail mode changed the existing file")
+		}
+	})
+
+	t.Run("backup", func(t *testing.T) {
+		path := existingFile(t, t.TempDir(), "out.csv")
+		existingFile(t, filepath.Dir(path), "out.csv.bak") // An older backup is replaced
+		got, err := resolveOutputPath(path, ExistingBackup, now, quietLogger())
+		if err != nil || got != path {
+			t.Errorf("resolveOutputPath() = %q, %v; want %q", got, err, path)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Error("backup mode left the file in place")
+		}
+		if fileContent(path+".bak") != "old" {
+			t.Error("backup mode didn't move the file to .bak")
+		}
+	})
+
+	t.Run("timestamp", func(t *testing.T) {
+		path := existingFile(t, t.TempDir(), "out.csv.gz")
+		got, err := resolveOutputPath(path, ExistingTimestamp, now, quietLogger())
+		want := filepath.Join(filepath.Dir(path), "out.20240305-143000.csv.gz")
+		if err != nil || got != want {
+			t.Errorf("resolveOutputPath() = %q, %v; want %q", got, err, want)
+		}
+		if fileContent(path) != "old" {
+			t.Error("timestamp mode changed the existing file")
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "out.csv")
+		for _, mode := range []ExistingFileMode{ExistingOverwrite, ExistingFail, ExistingBackup, ExistingTimestamp} {
+			if got, err := resolveOutputPath(path, mode, now, quietLogger()); err != nil || got != path {
+				t.Errorf("resolveOutputPath(%s) = %q, %v; want the path unchanged", mode, got, err)
+			}
+		}
+	})
+}
+
+// exportCSV writes results through NewWriter and returns the file written
+func exportCSV(t *testing.T, path string, compress bool, results []SearchResult) string {
+	t.Helper()
+	writer, err := NewWriter(ExportConfig{FilePath: path, Format: FormatCSV, IncludeHeader: true, Compress: compress}, quietLogger())
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	if err := writer.Initialize(); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if err := writer.WriteResults(context.Background(), results); err != nil {
+		t.Fatalf("WriteResults() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	return writer.Path()
+}
+
+func TestCompressedCSVRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	results := []SearchResult{
+		{ID: "W1", Title: "Soil carbon, revisited", Authors: []string{"Silva, João"}, Year: "2021"},
+		{ID: "W2", Title: "Adubação \"verde\" no cerrado", Year: "2019", OpenAccess: true, OpenAccessKnown: true},
+	}
+
+	plainPath := exportCSV(t, filepath.Join(dir, "plain"), false, results)
+	compressedPath := exportCSV(t, filepath.Join(dir, "compressed"), true, results)
+	if want := filepath.Join(dir, "compressed.csv.gz"); compressedPath != want {
+		t.Errorf("compressed export written to %s, want %s", compressedPath, want)
+	}
+
+	file, err := os.Open(compressedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("compressed export isn't gzip: %v", err)
+	}
+	unzipped, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("reading the compressed export: %v", err)
+	}
+
+	if plain := fileContent(plainPath); string(unzipped) != plain || plain == "" {
+		t.Errorf("gunzipped export = %q, want the uncompressed CSV %q", unzipped, plain)
+	}
+}
@@ -3,8 +3,10 @@ package result
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/alexandreffaria/reviu/internal/browser"
@@ -19,16 +21,87 @@ const (
 	ResultCountSelector = "span.fw-semibold.text-up-01.text-gray-60"
 	ResultsPerPage      = 30 // Number of results per page
 
-	DetailYearSelector   = "#item-ano"
-	DetailAuthorSelector = "a.view-autor"
+	// ResultsReadySelector signals that a results page has finished rendering
+	// It is kept separate from ResultLinkSelector so "ready to scrape" can be
+	// tuned independently of "what to scrape"
+	ResultsReadySelector = ResultLinkSelector
+
+	DetailYearSelector       = "#item-ano"
+	DetailAuthorSelector     = "a.view-autor"
+	DetailAbstractSelector   = "#item-resumo"
+	DetailOpenAccessSelector = "span[title=\"Acesso aberto\"]"
+	DetailCitationSelector   = "#item-citacao"
+	DetailDOISelector        = "#item-doi"
+	DetailJournalSelector    = "#item-fonte"
+	DetailKeywordsSelector   = "a.view-palavra-chave"
+	DetailISSNSelector       = "#item-issn"
+	DetailPublisherSelector  = "#item-editora"
+
+	// ConsentBannerSelector matches the gov.br cookie consent bar, which can
+	// cover the pagination controls until accepted
+	ConsentBannerSelector = "div.br-cookiebar"
+	ConsentAcceptSelector = "div.br-cookiebar button.br-button.primary"
+
+	// searchPagePath is part of every CAPES search and detail URL; landing
+	// anywhere else means the request was redirected
+	searchPagePath = "buscador.html"
 )
 
+// DetailReadySelectors are the fields of which at least one is on every
+// loaded details page; when none appears, the page failed to load, as
+// opposed to a publication that simply lacks, say, an author
+var DetailReadySelectors = []string{
+	DetailYearSelector,
+	DetailAuthorSelector,
+	DetailCitationSelector,
+	DetailJournalSelector,
+	DetailDOISelector,
+}
+
+// BlockPageSelectors match the captcha widgets shown instead of results
+var BlockPageSelectors = []string{
+	".g-recaptcha",
+	"iframe[src*=\"recaptcha\"]",
+	".h-captcha",
+	"iframe[src*=\"hcaptcha\"]",
+	"#challenge-form",
+	"#captcha",
+}
+
+// blockPageTexts are lowercase fragments of the messages on block pages
+var blockPageTexts = []string{
+	"acesso negado",
+	"access denied",
+	"too many requests",
+	"muitas requisições",
+	"request blocked",
+	"não sou um robô",
+}
+
 // CAPESResultExtractor extracts search results from CAPES search pages
 type CAPESResultExtractor struct {
 	log        logger.Logger
 	browser    browser.Browser
 	options    ProcessorOptions
 	collection *SearchCollection
+	throttle   *loadThrottle
+	limiter    *detailLimiter
+
+	// Detail pages are opened by a pool of long-lived browsers when a
+	// factory is set; errMu guards the collection errors they record
+	newDetailBrowser func() browser.Browser
+	detailWorkers    []*detailWorker
+	errMu            sync.Mutex
+
+	// progress is called after every page, if set
+	progress ProgressFunc
+
+	// stream receives the results of every page when StreamToDisk is set;
+	// streamSeen holds the dedup keys written so far
+	stream           ResultWriter
+	streamSeen       map[string]bool
+	streamExisting   *ExistingKeys
+	streamDuplicates int
 }
 
 // NewCAPESResultExtractor creates a new extractor
@@ -50,19 +123,45 @@ func (e *CAPESResultExtractor) SetOptions(options ProcessorOptions) {
 	e.options = options
 }
 
+// SetDetailBrowserFactory sets the function used to create detail browsers
+func (e *CAPESResultExtractor) SetDetailBrowserFactory(factory func() browser.Browser) {
+	e.newDetailBrowser = factory
+}
+
+// SetProgressFunc sets the function called after every processed page
+func (e *CAPESResultExtractor) SetProgressFunc(progress ProgressFunc) {
+	e.progress = progress
+}
+
+// SetStreamWriter sets the writer that receives the results of every page
+// when StreamToDisk is set, instead of keeping them in the collection
+func (e *CAPESResultExtractor) SetStreamWriter(writer ResultWriter) {
+	e.stream = writer
+}
+
+// SetExistingKeys sets the results already in the file being streamed to,
+// which are skipped like duplicates
+func (e *CAPESResultExtractor) SetExistingKeys(keys *ExistingKeys) {
+	e.streamExisting = keys
+}
+
+// resultsReadySelector returns the selector used to wait for results pages
+func (e *CAPESResultExtractor) resultsReadySelector() string {
+	if e.options.ResultsReadySelector != "" {
+		return e.options.ResultsReadySelector
+	}
+	return ResultsReadySelector
+}
+
 // extractTotalResults extracts the total number of search results from the page
-func (e *CAPESResultExtractor) extractTotalResults() (int, error) {
+func (e *CAPESResultExtractor) extractTotalResults(ctx context.Context) (int, error) {
 	// Get the text from the result count element
-	resultCountText, err := e.browser.GetElementText(ResultCountSelector)
+	resultCountText, err := e.browser.GetElementText(ctx, ResultCountSelector)
 	if err != nil {
 		return 0, errors.NewBrowserError("failed to find result count element", err)
 	}
 
-	// Parse the text to extract the number
-	// The text format is typically like "3.016 resultados"
-	resultCountText = strings.Replace(resultCountText, ".", "", -1) // Remove thousands separator
-	var count int
-	_, err = fmt.Sscanf(resultCountText, "%d resultados", &count)
+	count, err := parseResultCount(resultCountText)
 	if err != nil {
 		e.log.Warn("Failed to parse result count from '%s': %v", resultCountText, err)
 		// Return a default value
@@ -72,6 +171,43 @@ func (e *CAPESResultExtractor) extractTotalResults() (int, error) {
 	return count, nil
 }
 
+// parseResultCount parses the result count shown by CAPES
+// The text format is typically like "3.016 resultados"
+func parseResultCount(text string) (int, error) {
+	text = strings.Replace(text, ".", "", -1) // Remove thousands separator
+	var count int
+	if _, err := fmt.Sscanf(text, "%d resultados", &count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountResults opens the search URL and returns how many results CAPES
+// reports for it and how many pages they span, without extracting any
+func (e *CAPESResultExtractor) CountResults(ctx context.Context, searchURL string) (int, int, error) {
+	e.log.Info("Opening search URL to read the result count")
+	if err := e.browser.Open(ctx, searchURL); err != nil {
+		if errors.IsErrorType(err, errors.Network) {
+			return 0, 0, err
+		}
+		return 0, 0, errors.NewBrowserError("failed to open initial search URL", err)
+	}
+
+	text, err := e.browser.GetElementText(ctx, ResultCountSelector)
+	if err != nil {
+		return 0, 0, errors.NewBrowserError("failed to find result count element", err)
+	}
+
+	// Unlike a full run, a count has no use for a guessed total
+	count, err := parseResultCount(text)
+	if err != nil {
+		return 0, 0, errors.NewExternalError(fmt.Sprintf("could not read the result count from %q", text), err)
+	}
+
+	pages := (count + ResultsPerPage - 1) / ResultsPerPage
+	return count, pages, nil
+}
+
 // buildPageURL constructs a URL for a specific page
 func (e *CAPESResultExtractor) buildPageURL(baseURL string, page int) string {
 	// Check if the URL already has query parameters
@@ -95,40 +231,79 @@ func (e *CAPESResultExtractor) buildPageURL(baseURL string, page int) string {
 func (e *CAPESResultExtractor) Process(ctx context.Context, searchTerm string, searchURL string) (*SearchCollection, error) {
 	// Initialize collection
 	e.collection = NewSearchCollection(searchTerm)
+	e.streamSeen = make(map[string]bool)
+	e.streamDuplicates = 0
+	e.throttle = newLoadThrottle(e.options.ThrottleFactor, e.options.ThrottleWindow, e.options.ThrottleMaxDelay)
+	e.limiter = newDetailLimiter(e.options.DetailDelay)
+	if e.limiter.enabled() {
+		e.log.Info("Detail pages limited to about %.0f requests per minute (%v ± %.0f%% between requests)",
+			e.limiter.RequestsPerMinute(), e.options.DetailDelay, DetailDelayJitter*100)
+	}
+	defer e.closeDetailWorkers()
 
-	// Create a context with timeout
-	if e.options.Timeout > 0 {
+	// The deadline only stops the loop between pages, so it gets its own
+	// context instead of cancelling the page being extracted. Unlike the
+	// timeout, it is a wall-clock limit and keeps running while paused
+	deadlineCtx := ctx
+	if !e.options.Deadline.IsZero() {
 		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, time.Duration(e.options.Timeout)*time.Second)
+		deadlineCtx, cancel = context.WithDeadline(ctx, e.options.Deadline)
 		defer cancel()
+		e.log.Info("Run will stop after %s", e.options.Deadline.Format(time.RFC3339))
 	}
 
-	// Navigate to the initial search URL
+	// The timeout only counts the time spent extracting, not paused
+	timeout, ctx := newActiveTimeout(ctx, time.Duration(e.options.Timeout)*time.Second)
+	defer timeout.stop()
+
+	// Navigate to the initial search URL, or straight to the first page of
+	// the requested range
+	firstPage := e.firstPage()
+	if lastPage := e.resumeFromCheckpoint(searchURL); lastPage >= firstPage {
+		firstPage = lastPage + 1
+	}
+	initialURL := searchURL
+	if firstPage > 1 {
+		initialURL = e.buildPageURL(searchURL, firstPage)
+	}
 	e.log.Info("Navigating to initial search URL")
-	if err := e.browser.Open(searchURL); err != nil {
-		return nil, errors.NewBrowserError("failed to open initial search URL", err)
+	loadStart := time.Now()
+	attempts := 0
+	err := e.withRetry(ctx, "open the search URL", func() error {
+		// A failed launch may leave a browser behind, so close it and start
+		// a fresh one before retrying
+		if attempts > 0 {
+			if err := e.browser.Close(); err != nil {
+				e.log.Warn("Error closing previous browser instance: %v", err)
+			}
+		}
+		attempts++
+		return e.browser.Open(ctx, initialURL)
+	})
+	if err != nil {
+		if isPersistent(err) {
+			return nil, err
+		}
+		return nil, errors.NewBrowserError(fmt.Sprintf("failed to open initial search URL after %d attempt(s)", attempts), err)
 	}
+	e.throttle.Record(time.Since(loadStart))
 
 	// Extract total results to calculate total pages
-	totalResults, err := e.extractTotalResults()
+	totalResults, err := e.extractTotalResults(ctx)
 	if err != nil {
 		e.log.Warn("Could not determine total results: %v", err)
 		totalResults = 100 // Default value
 	}
+	e.collection.TotalFound = totalResults
 
-	// Calculate total pages
-	totalPages := (totalResults + ResultsPerPage - 1) / ResultsPerPage
-	e.log.Info("Found approximately %d total results across %d pages", totalResults, totalPages)
-
-	// Determine max pages to process
-	maxPagesToProcess := totalPages
-	if e.options.MaxPages > 0 && e.options.MaxPages < totalPages {
-		maxPagesToProcess = e.options.MaxPages
-		e.log.Info("Will process up to %d pages as specified by max-pages parameter", maxPagesToProcess)
+	// Determine how many pages to process
+	maxPagesToProcess := e.pagesToProcess(totalResults)
+	if firstPage > maxPagesToProcess {
+		e.log.Warn("Page range starts at page %d, but the search only has %d pages", firstPage, maxPagesToProcess)
 	}
 
 	// Process all pages using URL pagination
-	for currentPage := 1; currentPage <= maxPagesToProcess; currentPage++ {
+	for currentPage := firstPage; currentPage <= maxPagesToProcess; currentPage++ {
 		select {
 		case <-ctx.Done():
 			e.log.Warn("Processing stopped due to context cancellation or timeout")
@@ -137,47 +312,112 @@ func (e *CAPESResultExtractor) Process(ctx context.Context, searchTerm string, s
 			// Continue processing
 		}
 
-		pageURL := searchURL
+		if e.deadlineReached(ctx, deadlineCtx) {
+			break
+		}
+
+		// Hold here while the operator has the run paused, with the timeout
+		// clock stopped
+		if paused(e.options.PauseFile) {
+			timeout.pause()
+			err := waitWhilePaused(deadlineCtx, e.options.PauseFile, PausePollInterval, e.log)
+			ctx = timeout.resume()
+			if err != nil {
+				if e.deadlineReached(ctx, deadlineCtx) {
+					break
+				}
+				e.log.Warn("Processing stopped while paused")
+				return e.collection, err
+			}
+		}
+
+		pageURL := initialURL
 		// For the first page, we're already on the correct page
-		if currentPage > 1 {
+		if currentPage > firstPage {
 			// Navigate to the specific page using URL parameter
 			pageURL = e.buildPageURL(searchURL, currentPage)
 			e.log.Info("Navigating to page %d using URL: %s", currentPage, pageURL)
 
-			// Close the previous browser to avoid resource leaks
-			if err := e.browser.Close(); err != nil {
-				e.log.Warn("Error closing previous browser instance: %v", err)
-			}
-
-			// Open a new browser for this page
-			if err := e.browser.Open(pageURL); err != nil {
+			loadStart := time.Now()
+			err := e.withRetry(ctx, fmt.Sprintf("open page %d", currentPage), func() error {
+				return e.openPage(ctx, pageURL)
+			})
+			if err != nil {
 				e.log.Error("Failed to open page %d: %v", currentPage, err)
 				break
 			}
+			e.throttle.Record(time.Since(loadStart))
 		}
 
 		// Log current page
 		e.log.Info("Processing page %d", currentPage)
+		e.dismissConsentBanner(ctx)
 
 		// Extract results from current page
-		results, err := e.extractResultsFromCurrentPage(currentPage, pageURL)
-		if err != nil {
+		results, err := e.extractResultsFromCurrentPage(ctx, currentPage, pageURL)
+
+		// An empty page before the last one is almost always a failed load
+		if err == nil && len(results) == 0 && currentPage < maxPagesToProcess && e.options.RetryOnEmptyPage {
+			results, err = e.retryEmptyPage(ctx, currentPage, pageURL)
+		}
+
+		if errors.IsErrorType(err, errors.Network) {
+			// Retrying the remaining pages would only burn time; keep what we have
+			if err := e.addResults(results); err != nil {
+				return e.collection, err
+			}
+			e.collection.UpdatePageCount(currentPage)
+			e.log.Error("Stopping at page %d: %v", currentPage, err)
+			// With nothing collected, an export would look like an empty search
+			if e.collection.TotalResults == 0 {
+				return e.collection, err
+			}
+			break
+		} else if err != nil && ctx.Err() != nil {
+			// Keep the results detailed before the interruption
+			if err := e.addResults(results); err != nil {
+				return e.collection, err
+			}
+			e.collection.UpdatePageCount(currentPage)
+			e.log.Warn("Processing stopped on page %d after %d of its results", currentPage, len(results))
+			return e.collection, ctx.Err()
+		} else if err != nil {
 			e.log.Error("Failed to extract results from page %d: %v", currentPage, err)
 			// Continue to next page despite errors
 		} else {
 			// Add results to collection
-			e.collection.AddResults(results)
+			if err := e.addResults(results); err != nil {
+				return e.collection, err
+			}
 			e.log.Info("Extracted %d results from page %d", len(results), currentPage)
 		}
 
 		// Update collection metadata
 		e.collection.UpdatePageCount(currentPage)
+		e.saveCheckpoint(searchURL, currentPage)
+		if e.progress != nil {
+			e.progress(currentPage, maxPagesToProcess, e.collection.TotalResults)
+		}
+
+		// Stop as soon as the result limit has been reached, whatever pages remain
+		if e.maxResultsReached() {
+			e.log.Info("Reached the limit of %d results, stopping", e.options.MaxResults)
+			break
+		}
 
 		// Delay between page navigations to avoid being blocked
 		if currentPage < maxPagesToProcess {
-			if e.options.PageDelay > 0 {
-				e.log.Info("Waiting %v between pages to avoid blocking...", e.options.PageDelay)
-				time.Sleep(e.options.PageDelay)
+			delay := e.throttle.Delay(e.options.PageDelay)
+			if delay > e.options.PageDelay {
+				e.log.Warn("Page loads are slowing down (average %v), increasing delay to %v",
+					e.throttle.MovingAverage().Round(time.Millisecond), delay)
+			}
+			if delay > 0 {
+				e.log.Info("Waiting %v between pages to avoid blocking...", delay)
+				select {
+				case <-ctx.Done():
+				case <-time.After(delay):
+				}
 			}
 		}
 	}
@@ -188,19 +428,247 @@ func (e *CAPESResultExtractor) Process(ctx context.Context, searchTerm string, s
 	return e.collection, nil
 }
 
+// addResults adds the results of a page to the collection or, when
+// streaming, writes them to the export and only counts them
+func (e *CAPESResultExtractor) addResults(results []SearchResult) error {
+	if !e.options.StreamToDisk || e.stream == nil {
+		e.collection.AddResults(results)
+		return nil
+	}
+
+	// Nothing is left in memory to deduplicate later, so duplicates, and
+	// results the file being appended to already has, are dropped before
+	// they reach the file
+	if e.options.Dedup || e.streamExisting != nil {
+		unique := results[:0:0]
+		for _, result := range results {
+			key := dedupKey(result)
+			duplicate := e.options.Dedup && key != "" && e.streamSeen[key]
+			if duplicate || (e.streamExisting != nil && e.streamExisting.Contains(result)) {
+				e.streamDuplicates++
+				continue
+			}
+			e.streamSeen[key] = true
+			unique = append(unique, result)
+		}
+		results = unique
+	}
+
+	// Results already extracted are written even when the run is being
+	// interrupted, so the file matches the reported counts
+	if err := e.stream.WriteResults(context.Background(), results); err != nil {
+		return errors.NewExternalError("failed to write streamed results", err)
+	}
+	e.collection.TotalResults += len(results)
+	return nil
+}
+
+// StreamedDuplicates returns how many duplicates the last streaming run
+// dropped before writing
+func (e *CAPESResultExtractor) StreamedDuplicates() int {
+	return e.streamDuplicates
+}
+
+// openPage loads a results page, reusing the open browser when ReuseBrowser
+// is set and falling back to a fresh browser if navigating fails
+func (e *CAPESResultExtractor) openPage(ctx context.Context, pageURL string) error {
+	if e.options.ReuseBrowser {
+		err := e.browser.Navigate(ctx, pageURL)
+		if err == nil {
+			return nil
+		}
+		e.log.Warn("Failed to navigate to %s, reopening the browser: %v", pageURL, err)
+	}
+
+	// Close the previous browser to avoid resource leaks
+	if err := e.browser.Close(); err != nil {
+		e.log.Warn("Error closing previous browser instance: %v", err)
+	}
+
+	// Open a new browser for this page
+	return e.browser.Open(ctx, pageURL)
+}
+
+// resumeFromCheckpoint restores the results of an interrupted run of the same
+// search and returns the last page it completed, or 0 to start over
+func (e *CAPESResultExtractor) resumeFromCheckpoint(searchURL string) int {
+	if !e.options.Resume || e.options.CheckpointFile == "" {
+		return 0
+	}
+
+	checkpoint, err := loadCheckpoint(e.options.CheckpointFile)
+	if err != nil {
+		e.log.Warn("Could not resume from %s, starting over: %v", e.options.CheckpointFile, err)
+		return 0
+	}
+	if checkpoint == nil {
+		e.log.Info("No checkpoint found at %s, starting from the first page", e.options.CheckpointFile)
+		return 0
+	}
+	if checkpoint.SearchURL != searchURL {
+		e.log.Warn("Checkpoint %s belongs to a different search, starting over", e.options.CheckpointFile)
+		return 0
+	}
+
+	e.collection.AddResults(checkpoint.Results)
+	e.collection.Errors = append(e.collection.Errors, checkpoint.Errors...)
+	e.collection.UpdatePageCount(checkpoint.LastPage)
+	e.log.Info("Resuming after page %d with %d results from %s",
+		checkpoint.LastPage, len(checkpoint.Results), e.options.CheckpointFile)
+	return checkpoint.LastPage
+}
+
+// saveCheckpoint records the progress after lastPage, if checkpoints are enabled
+// Failing to save is logged but doesn't stop the run
+func (e *CAPESResultExtractor) saveCheckpoint(searchURL string, lastPage int) {
+	if e.options.CheckpointFile == "" {
+		return
+	}
+
+	if err := saveCheckpoint(e.options.CheckpointFile, searchURL, lastPage, e.collection); err != nil {
+		e.log.Warn("Failed to save checkpoint after page %d: %v", lastPage, err)
+	}
+}
+
+// deadlineReached reports whether the run deadline has passed while the run
+// itself is still live, meaning the loop should stop and keep its results
+func (e *CAPESResultExtractor) deadlineReached(ctx, deadlineCtx context.Context) bool {
+	if ctx.Err() != nil || deadlineCtx.Err() == nil {
+		return false
+	}
+
+	e.log.Info("Deadline reached after %d pages, stopping with partial results", e.collection.TotalPages)
+	return true
+}
+
+// retryEmptyPage reloads a page that returned no result links and extracts
+// it again, up to the configured number of retry attempts
+func (e *CAPESResultExtractor) retryEmptyPage(ctx context.Context, pageNum int, pageURL string) ([]SearchResult, error) {
+	maxRetries := e.options.RetryAttempts
+	if maxRetries <= 0 {
+		maxRetries = 3 // Fallback if not properly configured
+	}
+
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		e.log.Warn("Page %d returned no results, retrying (attempt %d of %d)", pageNum, attempt, maxRetries)
+		if err := e.browser.Reload(ctx); err != nil {
+			if errors.IsErrorType(err, errors.Network) {
+				return nil, err
+			}
+			e.log.Warn("Failed to reload page %d: %v", pageNum, err)
+			continue
+		}
+
+		results, err := e.extractResultsFromCurrentPage(ctx, pageNum, pageURL)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) > 0 {
+			e.log.Info("Recovered %d results from page %d on retry %d", len(results), pageNum, attempt)
+			return results, nil
+		}
+	}
+
+	e.log.Warn("Page %d still empty after %d retries, moving on", pageNum, maxRetries)
+	return []SearchResult{}, nil
+}
+
+// firstPage returns the page extraction starts at, 1 unless a page range is set
+func (e *CAPESResultExtractor) firstPage() int {
+	if e.options.PageRangeStart > 1 {
+		return e.options.PageRangeStart
+	}
+	return 1
+}
+
+// pagesToProcess computes the last page to visit for totalResults,
+// honouring the page range and, when no range is set, the max-pages option
+func (e *CAPESResultExtractor) pagesToProcess(totalResults int) int {
+	totalPages := (totalResults + ResultsPerPage - 1) / ResultsPerPage
+	e.log.Info("Found approximately %d total results across %d pages", totalResults, totalPages)
+
+	if e.options.PageRangeEnd > 0 {
+		if e.options.PageRangeEnd < totalPages {
+			totalPages = e.options.PageRangeEnd
+		}
+		e.log.Info("Will process pages %d to %d as specified by page-range parameter", e.firstPage(), totalPages)
+		return totalPages
+	}
+
+	if e.options.MaxPages > 0 && e.options.MaxPages < totalPages {
+		e.log.Info("Will process up to %d pages as specified by max-pages parameter", e.options.MaxPages)
+		return e.options.MaxPages
+	}
+
+	return totalPages
+}
+
+// PageURLs returns the URL of every results page a run would visit
+// When pages is 0, the search URL is opened to read the total result count;
+// otherwise the given page count is used without launching the browser
+func (e *CAPESResultExtractor) PageURLs(ctx context.Context, searchURL string, pages int) ([]string, error) {
+	if pages <= 0 {
+		e.log.Info("Opening search URL to determine the number of pages")
+		if err := e.browser.Open(ctx, searchURL); err != nil {
+			return nil, errors.NewBrowserError("failed to open initial search URL", err)
+		}
+
+		totalResults, err := e.extractTotalResults(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pages = e.pagesToProcess(totalResults)
+	} else if e.options.PageRangeEnd > 0 && e.options.PageRangeEnd < pages {
+		pages = e.options.PageRangeEnd
+	}
+
+	urls := make([]string, 0, pages)
+	for page := e.firstPage(); page <= pages; page++ {
+		// The first page is the search URL itself, as in Process
+		if page == 1 {
+			urls = append(urls, searchURL)
+			continue
+		}
+		urls = append(urls, e.buildPageURL(searchURL, page))
+	}
+
+	return urls, nil
+}
+
 // extractResultsFromCurrentPage extracts results from the current page
-func (e *CAPESResultExtractor) extractResultsFromCurrentPage(pageNum int, pageURL string) ([]SearchResult, error) {
+func (e *CAPESResultExtractor) extractResultsFromCurrentPage(ctx context.Context, pageNum int, pageURL string) ([]SearchResult, error) {
 	// Get all result links on the page
-	links, err := e.browser.ExtractLinks(ResultLinkSelector)
+	links, err := e.browser.ExtractLinks(ctx, ResultLinkSelector)
 	if err != nil {
+		e.recordError(pageURL, "links", err)
 		return nil, errors.NewBrowserError("failed to extract result links", err)
 	}
 
 	if len(links) == 0 {
 		e.log.Warn("No results found on page %d", pageNum)
+		if e.options.ScreenshotOnEmpty {
+			e.screenshotEmptyPage(ctx, pageNum)
+		}
+		if err := e.detectBlockPage(ctx); err != nil {
+			e.recordError(pageURL, "links", err)
+			if e.options.AbortOnBlock {
+				return nil, err
+			}
+			e.log.Warn("Page %d looks blocked, continuing: %v", pageNum, err)
+		}
 		return []SearchResult{}, nil
 	}
 
+	// Only detail as many links as the result limit still allows
+	if remaining := e.remainingResults(); remaining >= 0 && len(links) > remaining {
+		e.log.Debug("Limiting page %d to %d results due to the result limit", pageNum, remaining)
+		links = links[:remaining]
+	}
+
 	// Process each link into a search result
 	results := make([]SearchResult, 0, len(links))
 
@@ -214,62 +682,372 @@ func (e *CAPESResultExtractor) extractResultsFromCurrentPage(pageNum int, pageUR
 			PageFound: pageNum,
 			Position:  i + 1,
 		}
-
-		// Navigate to the detail page to extract author and year metadata
-		author, year := e.extractMetadataForResult(result.URL, pageURL)
-		result.Author = author
-		result.Year = year
+		if result.Title == "" {
+			e.recordError(pageURL, "title", fmt.Errorf("result %d has an empty title", i+1))
+		}
 
 		results = append(results, result)
 	}
 
+	// The fast mode stops at what the results page shows
+	if e.options.SkipDetails {
+		return results, nil
+	}
+
+	// Visit the detail pages to extract the remaining metadata
+	if e.newDetailBrowser != nil && e.options.DetailConcurrency > 0 {
+		return e.extractDetailsConcurrently(ctx, results)
+	}
+
+	for i := range results {
+		if err := ctx.Err(); err != nil {
+			return results[:i], err
+		}
+		if err := e.limiter.Wait(ctx); err != nil {
+			return results[:i], err
+		}
+		if err := e.extractMetadataForResult(ctx, &results[i], pageURL); err != nil {
+			return results[:i], err
+		}
+	}
+
 	return results, nil
 }
 
-// extractMetadataForResult navigates to the publication page and collects metadata
-func (e *CAPESResultExtractor) extractMetadataForResult(detailURL, returnURL string) (string, string) {
-	if detailURL == "" {
-		return "", ""
+// dismissConsentBanner accepts the cookie consent banner when the current page
+// shows one, so it can't intercept clicks such as the next page button
+// Once accepted, the banner stays away for the rest of the session
+func (e *CAPESResultExtractor) dismissConsentBanner(ctx context.Context) {
+	if exists, err := e.browser.ElementExists(ctx, ConsentBannerSelector); err != nil || !exists {
+		return
 	}
 
-	// Navigate to the detail page
-	if err := e.browser.Navigate(detailURL); err != nil {
-		e.log.Warn("Failed to open details page %s: %v", detailURL, err)
-		return "", ""
+	if err := e.browser.ClickElement(ctx, ConsentAcceptSelector); err != nil {
+		e.log.Warn("Failed to dismiss the cookie consent banner: %v", err)
+		return
 	}
+	e.log.Debug("Dismissed the cookie consent banner")
+}
 
-	timeout := time.Duration(e.options.PageTimeout) * time.Second
-	if timeout <= 0 {
-		timeout = 15 * time.Second
+// detectBlockPage checks whether the current page is a block or captcha page
+// served by CAPES instead of search results, returning a network error if so
+// Checks that fail to run are ignored, so only positive evidence counts
+func (e *CAPESResultExtractor) detectBlockPage(ctx context.Context) error {
+	for _, selector := range BlockPageSelectors {
+		if exists, err := e.browser.ElementExists(ctx, selector); err == nil && exists {
+			return errors.WithCode(errors.NewNetworkError(fmt.Sprintf("CAPES served a captcha page (%s)", selector), nil), errors.CodeBlocked)
+		}
 	}
 
-	// Wait for the details to load
-	if err := e.browser.WaitForElement(DetailYearSelector, timeout); err != nil {
-		e.log.Debug("Year element not found on detail page %s: %v", detailURL, err)
+	if text, err := e.browser.GetElementText(ctx, "body"); err == nil {
+		lower := strings.ToLower(text)
+		for _, fragment := range blockPageTexts {
+			if strings.Contains(lower, fragment) {
+				return errors.WithCode(errors.NewNetworkError(fmt.Sprintf("CAPES blocked the request (page says %q)", fragment), nil), errors.CodeBlocked)
+			}
+		}
 	}
 
-	author := e.extractAuthorsFromDetail()
-	year := e.extractYearFromDetail()
+	if pageURL, err := e.browser.PageURL(ctx); err == nil && pageURL != "" && !strings.Contains(pageURL, searchPagePath) {
+		return errors.WithCode(errors.NewNetworkError(fmt.Sprintf("CAPES redirected away from the search to %s", pageURL), nil), errors.CodeBlocked)
+	}
+
+	return nil
+}
+
+// screenshotEmptyPage saves a screenshot of a results page without links, so
+// a block or captcha page can be told apart from a genuinely empty search
+// Failures are only logged, since the screenshot is a debugging aid
+func (e *CAPESResultExtractor) screenshotEmptyPage(ctx context.Context, pageNum int) {
+	dir := e.options.ScreenshotDir
+	if dir == "" {
+		dir = DefaultScreenshotDir
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("page-%03d.png", pageNum))
+	if err := e.browser.Screenshot(ctx, path); err != nil {
+		e.log.Warn("Could not save screenshot of empty page %d: %v", pageNum, err)
+		return
+	}
+	e.log.Info("Saved screenshot of empty page %d to %s", pageNum, path)
+}
+
+// extractMetadataForResult navigates to the publication page and fills in the
+// metadata fields of result, retrying pages that fail to load
+// Failures are recorded on the collection; only network errors, which would
+// hit every remaining result as well, and cancellation are returned
+func (e *CAPESResultExtractor) extractMetadataForResult(ctx context.Context, result *SearchResult, returnURL string) error {
+	detailURL := result.URL
+	if detailURL == "" {
+		return nil
+	}
+
+	// Navigate to the detail page
+	timeout := e.pageTimeout()
+	err := e.withRetry(ctx, "load details page "+detailURL, func() error {
+		if err := e.browser.Navigate(ctx, detailURL); err != nil {
+			return err
+		}
+		return e.waitForDetails(ctx, e.browser, timeout)
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		e.abandonDetails(detailURL, err)
+		if errors.IsErrorType(err, errors.Network) {
+			return err
+		}
+		return nil
+	}
+
+	e.extractDetailFields(ctx, e.browser, result)
+
+	// Fields read while the run was being cancelled may be incomplete, so
+	// the result is left out like the ones not yet visited
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
 
 	// Navigate back to the search results page to continue processing
-	if err := e.browser.Navigate(returnURL); err != nil {
+	if err := e.browser.Navigate(ctx, returnURL); err != nil {
 		e.log.Warn("Failed to return to results page from %s: %v", detailURL, err)
-		return author, year
+		e.recordError(returnURL, "results", err)
+		if errors.IsErrorType(err, errors.Network) {
+			return err
+		}
+		return nil
 	}
 
-	if err := e.browser.WaitForElement(ResultLinkSelector, timeout); err != nil {
+	if err := e.browser.WaitForElement(ctx, e.resultsReadySelector(), timeout); err != nil {
 		e.log.Debug("Results did not finish loading after returning from %s: %v", detailURL, err)
 	}
+	return nil
+}
+
+// waitForDetails waits until the details page open in b shows any of its
+// fields, returning an error when it didn't load in time
+func (e *CAPESResultExtractor) waitForDetails(ctx context.Context, b browser.Browser, timeout time.Duration) error {
+	if _, err := b.WaitForAnyElement(ctx, DetailReadySelectors, timeout); err != nil {
+		return errors.NewBrowserError("details page did not load", err)
+	}
+	return nil
+}
+
+// abandonDetails records a details page that couldn't be loaded after all
+// retries, whose row keeps only the data from the results page
+func (e *CAPESResultExtractor) abandonDetails(detailURL string, err error) {
+	e.log.Warn("Giving up on details page %s, its row will lack author, year and other details: %v", detailURL, err)
+	e.recordError(detailURL, "details", err)
+}
+
+// extractDetailFields fills in the metadata of result from the detail page
+// currently open in b
+func (e *CAPESResultExtractor) extractDetailFields(ctx context.Context, b browser.Browser, result *SearchResult) {
+	detailURL := result.URL
+
+	var err error
+	if result.Authors, err = e.extractAuthorsFromDetail(ctx, b); err != nil {
+		e.recordError(detailURL, "authors", err)
+	}
+	result.Author = strings.Join(result.Authors, ", ")
+	if e.options.NormalizeAuthors {
+		result.NormalizedAuthor = strings.Join(NormalizeAuthorNames(result.Authors), "; ")
+	}
+	if result.Year, err = e.extractYearFromDetail(ctx, b); err != nil {
+		e.recordError(detailURL, "year", err)
+	}
+	result.OpenAccess, err = e.extractOpenAccessFromDetail(ctx, b)
+	result.OpenAccessKnown = err == nil
+	if err != nil {
+		e.recordError(detailURL, "openAccess", err)
+	}
+
+	if e.shouldExtractAbstract(result) {
+		if result.Abstract, err = e.extractAbstractFromDetail(ctx, b); err != nil {
+			e.recordError(detailURL, "abstract", err)
+		}
+	}
+	result.Citation = e.extractCitationFromDetail(ctx, b)
+	result.DOI = e.extractDOIFromDetail(ctx, b)
+	result.Journal = e.extractJournalFromDetail(ctx, b)
+	result.ISSN = e.extractISSNFromDetail(ctx, b)
+	result.Publisher = e.extractPublisherFromDetail(ctx, b)
+	result.Keywords = e.extractKeywordsFromDetail(ctx, b)
+}
+
+// shouldExtractAbstract decides whether the abstract of result is worth fetching
+// In open-access-only mode, abstracts are skipped for closed publications
+func (e *CAPESResultExtractor) shouldExtractAbstract(result *SearchResult) bool {
+	if !e.options.ExtractAbstracts {
+		return false
+	}
+
+	if e.options.AbstractsOpenAccessOnly && !result.OpenAccess {
+		e.log.Debug("Skipping abstract for non open access result: %s", result.URL)
+		return false
+	}
+
+	return true
+}
+
+// extractOpenAccessFromDetail checks the details page for the open access badge
+// An error means the status couldn't be determined
+func (e *CAPESResultExtractor) extractOpenAccessFromDetail(ctx context.Context, b browser.Browser) (bool, error) {
+	exists, err := b.ElementExists(ctx, DetailOpenAccessSelector)
+	if err != nil {
+		e.log.Warn("Could not check open access status on detail page: %v", err)
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// extractAbstractFromDetail collects the abstract from the details page
+// Many publications have no abstract; that leaves it empty without an error
+func (e *CAPESResultExtractor) extractAbstractFromDetail(ctx context.Context, b browser.Browser) (string, error) {
+	exists, err := b.ElementExists(ctx, DetailAbstractSelector)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", nil
+	}
+
+	abstractText, err := b.GetElementText(ctx, DetailAbstractSelector)
+	if err != nil {
+		e.log.Debug("No abstract found on detail page: %v", err)
+		return "", err
+	}
+
+	return cleanTitle(abstractText), nil
+}
+
+// extractCitationFromDetail collects the ready-made citation from the details
+// page, which only some publications have
+func (e *CAPESResultExtractor) extractCitationFromDetail(ctx context.Context, b browser.Browser) string {
+	exists, err := b.ElementExists(ctx, DetailCitationSelector)
+	if err != nil || !exists {
+		return ""
+	}
+
+	citationText, err := b.GetElementText(ctx, DetailCitationSelector)
+	if err != nil {
+		e.log.Debug("Could not extract citation from detail page: %v", err)
+		return ""
+	}
 
-	return author, year
+	return cleanCitation(citationText)
+}
+
+// extractDOIFromDetail collects the DOI from the details page in its bare
+// "10.xxxx/..." form, or returns an empty string when there is none
+func (e *CAPESResultExtractor) extractDOIFromDetail(ctx context.Context, b browser.Browser) string {
+	exists, err := b.ElementExists(ctx, DetailDOISelector)
+	if err != nil || !exists {
+		return ""
+	}
+
+	doiText, err := b.GetElementText(ctx, DetailDOISelector)
+	if err != nil {
+		e.log.Debug("Could not extract DOI from detail page: %v", err)
+		return ""
+	}
+
+	return BareDOI(doiText)
+}
+
+// nonJournalSourceMarkers are lowercase fragments of the sources of books
+// and conference papers, which CAPES shows in the same element as journals
+var nonJournalSourceMarkers = []string{
+	"isbn",
+	"livro",
+	"book",
+	"anais",
+	"proceedings",
+	"conference",
+	"congresso",
+	"simpósio",
+	"symposium",
+	"workshop",
+}
+
+// extractJournalFromDetail collects the name of the journal from the details
+// page, or returns an empty string when there is none or the source is a
+// book or a conference
+func (e *CAPESResultExtractor) extractJournalFromDetail(ctx context.Context, b browser.Browser) string {
+	exists, err := b.ElementExists(ctx, DetailJournalSelector)
+	if err != nil || !exists {
+		return ""
+	}
+
+	sourceText, err := b.GetElementText(ctx, DetailJournalSelector)
+	if err != nil {
+		e.log.Debug("Could not extract journal from detail page: %v", err)
+		return ""
+	}
+
+	source := cleanTitle(sourceText)
+	lower := strings.ToLower(source)
+	for _, marker := range nonJournalSourceMarkers {
+		if strings.Contains(lower, marker) {
+			e.log.Debug("Source %q is not a journal, leaving it out", source)
+			return ""
+		}
+	}
+	return source
+}
+
+// extractISSNFromDetail collects the ISSN of the journal from the details
+// page in its "XXXX-XXXX" form, or returns an empty string when there is none
+// An ISSN whose check digit doesn't match is kept, since CAPES may show a
+// typo of the real one, but logged so it can be checked by hand
+func (e *CAPESResultExtractor) extractISSNFromDetail(ctx context.Context, b browser.Browser) string {
+	exists, err := b.ElementExists(ctx, DetailISSNSelector)
+	if err != nil || !exists {
+		return ""
+	}
+
+	issnText, err := b.GetElementText(ctx, DetailISSNSelector)
+	if err != nil {
+		e.log.Debug("Could not extract ISSN from detail page: %v", err)
+		return ""
+	}
+
+	issn := NormalizeISSN(issnText)
+	if issn == "" {
+		e.log.Debug("No ISSN found in %q", strings.TrimSpace(issnText))
+		return ""
+	}
+	if !ValidISSN(issn) {
+		e.log.Warn("ISSN %s has an invalid check digit", issn)
+	}
+	return issn
+}
+
+// extractPublisherFromDetail collects the publisher from the details page,
+// or returns an empty string when there is none
+func (e *CAPESResultExtractor) extractPublisherFromDetail(ctx context.Context, b browser.Browser) string {
+	exists, err := b.ElementExists(ctx, DetailPublisherSelector)
+	if err != nil || !exists {
+		return ""
+	}
+
+	publisherText, err := b.GetElementText(ctx, DetailPublisherSelector)
+	if err != nil {
+		e.log.Debug("Could not extract publisher from detail page: %v", err)
+		return ""
+	}
+
+	return cleanTitle(publisherText)
 }
 
 // extractAuthorsFromDetail collects author names from the details page
-func (e *CAPESResultExtractor) extractAuthorsFromDetail() string {
-	authorElements, err := e.browser.GetElements(DetailAuthorSelector)
+func (e *CAPESResultExtractor) extractAuthorsFromDetail(ctx context.Context, b browser.Browser) ([]string, error) {
+	authorElements, err := b.GetElements(ctx, DetailAuthorSelector)
 	if err != nil {
 		e.log.Warn("Could not extract authors from detail page: %v", err)
-		return ""
+		return nil, err
 	}
 
 	var authors []string
@@ -285,26 +1063,88 @@ func (e *CAPESResultExtractor) extractAuthorsFromDetail() string {
 		}
 	}
 
-	return strings.Join(authors, ", ")
+	return authors, nil
+}
+
+// extractKeywordsFromDetail collects the author keywords from the details
+// page, trimmed and without repeats, or nil when the page has none
+func (e *CAPESResultExtractor) extractKeywordsFromDetail(ctx context.Context, b browser.Browser) []string {
+	exists, err := b.ElementExists(ctx, DetailKeywordsSelector)
+	if err != nil || !exists {
+		return nil
+	}
+
+	keywordElements, err := b.GetElements(ctx, DetailKeywordsSelector)
+	if err != nil {
+		e.log.Debug("Could not extract keywords from detail page: %v", err)
+		return nil
+	}
+
+	var keywords []string
+	seen := make(map[string]bool)
+	for _, element := range keywordElements {
+		keyword, err := element.Text()
+		if err != nil {
+			continue
+		}
+
+		keyword = cleanTitle(keyword)
+		key := strings.ToLower(keyword)
+		if keyword != "" && !seen[key] {
+			seen[key] = true
+			keywords = append(keywords, keyword)
+		}
+	}
+
+	return keywords
 }
 
 // extractYearFromDetail collects the publication year from the details page
-func (e *CAPESResultExtractor) extractYearFromDetail() string {
-	yearText, err := e.browser.GetElementText(DetailYearSelector)
+func (e *CAPESResultExtractor) extractYearFromDetail(ctx context.Context, b browser.Browser) (string, error) {
+	yearText, err := b.GetElementText(ctx, DetailYearSelector)
 	if err != nil {
 		e.log.Warn("Could not extract year from detail page: %v", err)
-		return ""
+		return "", err
 	}
 
 	year := strings.TrimSpace(yearText)
 	year = strings.TrimSuffix(year, ";")
-	return strings.TrimSpace(year)
+	return strings.TrimSpace(year), nil
+}
+
+// recordError adds a failed field extraction to the collection being built
+// It is safe to call from the detail workers
+func (e *CAPESResultExtractor) recordError(url, field string, err error) {
+	e.errMu.Lock()
+	defer e.errMu.Unlock()
+	if e.collection != nil {
+		e.collection.AddExtractionError(url, field, err)
+	}
+}
+
+// remainingResults returns how many more results may be extracted under
+// MaxResults, or -1 when no limit is configured
+func (e *CAPESResultExtractor) remainingResults() int {
+	if e.options.MaxResults <= 0 {
+		return -1
+	}
+
+	remaining := e.options.MaxResults - e.collection.TotalResults
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// maxResultsReached reports whether MaxResults has been satisfied
+func (e *CAPESResultExtractor) maxResultsReached() bool {
+	return e.remainingResults() == 0
 }
 
 // hasNextPage checks if there's a next page button
-func (e *CAPESResultExtractor) hasNextPage() (bool, error) {
+func (e *CAPESResultExtractor) hasNextPage(ctx context.Context) (bool, error) {
 	// Check if next page button exists
-	exists, err := e.browser.ElementExists(NextPageSelector)
+	exists, err := e.browser.ElementExists(ctx, NextPageSelector)
 	if err != nil {
 		return false, errors.NewBrowserError("failed to check for next page button", err)
 	}
@@ -312,13 +1152,10 @@ func (e *CAPESResultExtractor) hasNextPage() (bool, error) {
 	return exists, nil
 }
 
-// goToNextPage clicks the next page button with retry logic
-func (e *CAPESResultExtractor) goToNextPage() error {
-	// Get configuration values from options
-	maxRetries := e.options.RetryAttempts
-	if maxRetries <= 0 {
-		maxRetries = 3 // Fallback if not properly configured
-	}
+// goToNextPage clicks the next page button, backing off exponentially
+// between failed attempts
+func (e *CAPESResultExtractor) goToNextPage(ctx context.Context) error {
+	maxRetries := e.retryOptions().MaxAttempts
 
 	baseTimeout := time.Duration(e.options.NavigationTimeout) * time.Second
 	if baseTimeout <= 0 {
@@ -332,14 +1169,14 @@ func (e *CAPESResultExtractor) goToNextPage() error {
 		e.log.Debug("Scrolling to ensure next page button is loaded")
 
 		// Try scrolling to bottom first
-		if err := e.browser.ScrollToBottom(); err != nil {
+		if err := e.browser.ScrollToBottom(ctx); err != nil {
 			e.log.Warn("Error scrolling to bottom: %v", err)
 			// Continue anyway
 		}
 
 		// Then continuous scrolling to trigger lazy loading
 		e.log.Debug("Performing continuous scrolling for 3 seconds")
-		if err := e.browser.ScrollForDuration(3 * time.Second); err != nil {
+		if err := e.browser.ScrollForDuration(ctx, 3*time.Second); err != nil {
 			e.log.Warn("Error during continuous scrolling: %v", err)
 			// Continue anyway
 		}
@@ -348,43 +1185,48 @@ func (e *CAPESResultExtractor) goToNextPage() error {
 		time.Sleep(1 * time.Second)
 
 		// Click next page button
-		if err := e.browser.ClickElement(NextPageSelector); err != nil {
+		if err := e.browser.ClickElement(ctx, NextPageSelector); err != nil {
 			e.log.Warn("Failed to click next page button (attempt %d): %v", attempt, err)
 			if attempt == maxRetries {
 				return errors.NewBrowserError("failed to click next page button after multiple attempts", err)
 			}
-			time.Sleep(1 * time.Second)
+			if err := e.backoff(ctx, attempt); err != nil {
+				return err
+			}
 			continue
 		}
 
-		// Increase timeout for each retry
-		timeout := baseTimeout + time.Duration(attempt-1)*5*time.Second
-
 		// Wait for navigation with the configured timeout
 		navigationTimeout := time.Duration(e.options.NavigationTimeout) * time.Second
 		if navigationTimeout <= 0 {
-			navigationTimeout = timeout // Use fallback if not configured
+			navigationTimeout = baseTimeout // Use fallback if not configured
 		}
 
-		if err := e.browser.WaitForNavigation(navigationTimeout); err != nil {
+		if err := e.browser.WaitForNavigation(ctx, navigationTimeout); err != nil {
 			e.log.Warn("Failed waiting for navigation (attempt %d): %v", attempt, err)
 			if attempt == maxRetries {
 				return errors.NewBrowserError("failed waiting for navigation after multiple attempts", err)
 			}
+			if err := e.backoff(ctx, attempt); err != nil {
+				return err
+			}
 			continue
 		}
 
 		// Wait for results to load using page timeout
 		resultTimeout := time.Duration(e.options.PageTimeout) * time.Second
 		if resultTimeout <= 0 {
-			resultTimeout = timeout + 5*time.Second // Use fallback if not configured
+			resultTimeout = baseTimeout + 5*time.Second // Use fallback if not configured
 		}
 
-		if err := e.browser.WaitForElement(ResultLinkSelector, resultTimeout); err != nil {
+		if err := e.browser.WaitForElement(ctx, e.resultsReadySelector(), resultTimeout); err != nil {
 			e.log.Warn("Failed waiting for results to load (attempt %d): %v", attempt, err)
 			if attempt == maxRetries {
 				return errors.NewBrowserError("failed waiting for results to load after multiple attempts", err)
 			}
+			if err := e.backoff(ctx, attempt); err != nil {
+				return err
+			}
 			continue
 		}
 
@@ -410,6 +1252,12 @@ func cleanTitle(title string) string {
 	return title
 }
 
+// cleanCitation collapses the line breaks and indentation of a citation
+// rendered across several lines into a single line
+func cleanCitation(citation string) string {
+	return strings.Join(strings.Fields(citation), " ")
+}
+
 // absoluteURL converts relative URLs to absolute URLs
 func absoluteURL(urlStr string) string {
 	if strings.HasPrefix(urlStr, "http") {
@@ -0,0 +1,481 @@
+package result
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/alexandreffaria/reviu/internal/browser"
+	"github.com/alexandreffaria/reviu/internal/browser/browsertest"
+	"github.com/alexandreffaria/reviu/internal/errors"
+	"github.com/alexandreffaria/reviu/internal/logger"
+)
+
+// testSearchURL is the search the extractor tests run against
+const testSearchURL = "https://www.periodicos.capes.gov.br/index.php/acervo/buscador.html?q=soil"
+
+// quietLogger returns a logger that discards everything
+func quietLogger() logger.Logger {
+	return logger.NewLogger(logger.WithWriter(io.Discard))
+}
+
+// testOptions returns processor options that never sleep
+func testOptions() ProcessorOptions {
+	options := DefaultProcessorOptions()
+	options.Timeout = 0
+	options.PageDelay = 0
+	options.Retry.InitialDelay = 1
+	options.Retry.MaxDelay = 1
+	return options
+}
+
+// newTestExtractor creates an extractor over fake with testOptions, changed by configure
+func newTestExtractor(fake *browsertest.FakeBrowser, configure func(*ProcessorOptions)) *CAPESResultExtractor {
+	extractor := NewCAPESResultExtractor(fake, quietLogger())
+	options := testOptions()
+	if configure != nil {
+		configure(&options)
+	}
+	extractor.SetOptions(options)
+	return extractor
+}
+
+// detailURL returns the details page of publication id
+func detailURL(id string) string {
+	return "https://www.periodicos.capes.gov.br/index.php/acervo/buscador.html?task=detalhes&id=" + id
+}
+
+// scriptSearch scripts a search of total results on fake, serving perPage
+// links on each of the given pages, with a details page for every link
+// showing its year. The IDs are "W<page>-<position>"
+func scriptSearch(fake *browsertest.FakeBrowser, total int, perPage []int) {
+	for i, count := range perPage {
+		page := i + 1
+		pageURL := testSearchURL
+		if page > 1 {
+			pageURL = fmt.Sprintf("%s&page=%d", testSearchURL, page)
+		}
+
+		links := resultLinks(page, count)
+		for _, link := range links {
+			fake.SetText(link.URL, DetailYearSelector, "2021;")
+		}
+		fake.SetLinks(pageURL, ResultLinkSelector, links)
+		fake.SetText(pageURL, ResultCountSelector, fmt.Sprintf("%d resultados", total))
+	}
+}
+
+// resultLinks returns the count result links scriptSearch serves on page
+func resultLinks(page, count int) []browser.LinkData {
+	var links []browser.LinkData
+	for position := 1; position <= count; position++ {
+		id := fmt.Sprintf("W%d-%d", page, position)
+		links = append(links, browser.LinkData{Text: "  Title " + id + " ", URL: detailURL(id)})
+	}
+	return links
+}
+
+func TestProcessWithFakeBrowser(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	scriptSearch(fake, 45, []int{2, 1})
+
+	extractor := newTestExtractor(fake, nil)
+	collection, err := extractor.Process(context.Background(), "soil", testSearchURL)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if collection.TotalResults != 3 || collection.TotalPages != 2 {
+		t.Fatalf("Process() = %d results on %d pages, want 3 on 2", collection.TotalResults, collection.TotalPages)
+	}
+	want := []struct{ id, title string }{{"W1-1", "Title W1-1"}, {"W1-2", "Title W1-2"}, {"W2-1", "Title W2-1"}}
+	for i, result := range collection.Results {
+		if result.ID != want[i].id || result.Title != want[i].title || result.Year != "2021" {
+			t.Errorf("result %d = {ID %q, Title %q, Year %q}, want {%q, %q, 2021}",
+				i, result.ID, result.Title, result.Year, want[i].id, want[i].title)
+		}
+	}
+
+	if got := fake.CallCount("Open"); got != 1 {
+		t.Errorf("Open called %d times, want once for the search", got)
+	}
+	if got := fake.CallCount("Reload"); got != 0 {
+		t.Errorf("Reload called %d times, want none when every page has results", got)
+	}
+	if got := fake.CurrentURL(); got != testSearchURL+"&page=2" {
+		t.Errorf("browser ended on %q, want the last results page", got)
+	}
+}
+
+// detailVisits returns how many times fake navigated to a details page
+func detailVisits(fake *browsertest.FakeBrowser) int {
+	visits := 0
+	for _, call := range fake.Calls() {
+		if call.Method == "Navigate" && strings.Contains(call.Args[0], "task=detalhes") {
+			visits++
+		}
+	}
+	return visits
+}
+
+func TestProcessHeadLimitsDetailVisits(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	scriptSearch(fake, 90, []int{3, 3, 3})
+
+	extractor := newTestExtractor(fake, func(options *ProcessorOptions) {
+		options.MaxResults = 4
+	})
+	collection, err := extractor.Process(context.Background(), "soil", testSearchURL)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if collection.TotalResults != 4 {
+		t.Errorf("Process() kept %d results, want 4", collection.TotalResults)
+	}
+	if got := detailVisits(fake); got != 4 {
+		t.Errorf("visited %d details pages, want exactly 4", got)
+	}
+	if collection.TotalPages != 2 {
+		t.Errorf("processed %d pages, want the loop to stop on page 2", collection.TotalPages)
+	}
+}
+
+func TestProcessAbstractsOpenAccessOnly(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	scriptSearch(fake, 2, []int{2})
+	fake.SetText(detailURL("W1-1"), DetailOpenAccessSelector, "")
+	fake.SetText(detailURL("W1-1"), DetailAbstractSelector, "Open abstract")
+	fake.SetText(detailURL("W1-2"), DetailAbstractSelector, "Closed abstract")
+
+	for _, oaOnly := range []bool{false, true} {
+		extractor := newTestExtractor(fake, func(options *ProcessorOptions) {
+			options.ExtractAbstracts = true
+			options.AbstractsOpenAccessOnly = oaOnly
+		})
+		collection, err := extractor.Process(context.Background(), "soil", testSearchURL)
+		if err != nil {
+			t.Fatalf("Process(oa-only %v) error = %v", oaOnly, err)
+		}
+
+		open, closed := collection.Results[0], collection.Results[1]
+		if !open.OpenAccess || closed.OpenAccess {
+			t.Fatalf("open access = %v, %v; want true, false", open.OpenAccess, closed.OpenAccess)
+		}
+		if open.Abstract != "Open abstract" {
+			t.Errorf("oa-only %v: open access abstract = %q, want it fetched", oaOnly, open.Abstract)
+		}
+		wantClosed := "Closed abstract"
+		if oaOnly {
+			wantClosed = ""
+		}
+		if closed.Abstract != wantClosed {
+			t.Errorf("oa-only %v: closed abstract = %q, want %q", oaOnly, closed.Abstract, wantClosed)
+		}
+	}
+}
+
+func TestExtractOpenAccessFromDetail(t *testing.T) {
+	const page = "https://www.periodicos.capes.gov.br/detail"
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		badge     bool
+		fail      error
+		wantOA    bool
+		wantLabel string
+	}{
+		{"badge present", true, nil, true, "Sim"},
+		{"badge absent", false, nil, false, "Não"},
+		{"status unknown", false, fmt.Errorf("page crashed"), false, ""},
+	}
+
+	for _, tt := range tests {
+		fake := browsertest.NewFakeBrowser()
+		fake.SetText(page, DetailYearSelector, "2020")
+		if tt.badge {
+			fake.SetText(page, DetailOpenAccessSelector, "")
+		}
+		fake.FailOn("ElementExists", tt.fail)
+		fake.Open(ctx, page)
+
+		extractor := newTestExtractor(fake, nil)
+		oa, err := extractor.extractOpenAccessFromDetail(ctx, fake)
+		if (err != nil) != (tt.fail != nil) {
+			t.Errorf("%s: extractOpenAccessFromDetail() error = %v", tt.name, err)
+		}
+		result := SearchResult{OpenAccess: oa, OpenAccessKnown: err == nil}
+		if oa != tt.wantOA || result.OpenAccessLabel() != tt.wantLabel {
+			t.Errorf("%s: open access = %v labelled %q, want %v labelled %q",
+				tt.name, oa, result.OpenAccessLabel(), tt.wantOA, tt.wantLabel)
+		}
+	}
+}
+
+// reloadingBrowser is a fake that runs onReload after every successful
+// Reload, so a test can change what the reloaded page serves
+type reloadingBrowser struct {
+	*browsertest.FakeBrowser
+	onReload func()
+}
+
+func (b *reloadingBrowser) Reload(ctx context.Context) error {
+	if err := b.FakeBrowser.Reload(ctx); err != nil {
+		return err
+	}
+	b.onReload()
+	return nil
+}
+
+// emptyFirstPage scripts a two-page search whose first page serves no links
+// until it's reloaded once
+func emptyFirstPage(fake *browsertest.FakeBrowser) *reloadingBrowser {
+	scriptSearch(fake, 45, []int{2, 1})
+	fake.SetLinks(testSearchURL, ResultLinkSelector, nil)
+
+	reloads := 0
+	return &reloadingBrowser{FakeBrowser: fake, onReload: func() {
+		if reloads++; reloads == 1 {
+			fake.SetLinks(testSearchURL, ResultLinkSelector, resultLinks(1, 2))
+		}
+	}}
+}
+
+func TestProcessRetriesEmptyPage(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	reloading := emptyFirstPage(fake)
+
+	extractor := NewCAPESResultExtractor(reloading, quietLogger())
+	extractor.SetOptions(testOptions())
+	collection, err := extractor.Process(context.Background(), "soil", testSearchURL)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if collection.TotalResults != 3 {
+		t.Fatalf("Process() kept %d results, want the 2 recovered on retry and 1 from page 2", collection.TotalResults)
+	}
+	if first := collection.Results[0]; first.ID != "W1-1" || first.PageFound != 1 {
+		t.Errorf("first result = %q from page %d, want W1-1 from page 1", first.ID, first.PageFound)
+	}
+}
+
+func TestProcessGivesUpOnEmptyPage(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	scriptSearch(fake, 45, []int{2, 1})
+	fake.SetLinks(testSearchURL, ResultLinkSelector, nil)
+
+	extractor := newTestExtractor(fake, func(options *ProcessorOptions) {
+		options.RetryAttempts = 2
+	})
+	collection, err := extractor.Process(context.Background(), "soil", testSearchURL)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if got := fake.CallCount("Reload"); got != 2 {
+		t.Errorf("reloaded %d times, want the 2 retries allowed", got)
+	}
+	if collection.TotalResults != 1 || collection.Results[0].ID != "W2-1" {
+		t.Errorf("Process() = %d results, want only the one on page 2", collection.TotalResults)
+	}
+}
+
+func TestProcessReloadsEmptyPage(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	extractor := NewCAPESResultExtractor(emptyFirstPage(fake), quietLogger())
+	extractor.SetOptions(testOptions())
+	if _, err := extractor.Process(context.Background(), "soil", testSearchURL); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if got := fake.CallCount("Reload"); got != 1 {
+		t.Fatalf("reloaded %d times, want once for the empty first page", got)
+	}
+
+	// The reload happens between two link extractions, and the second one
+	// feeds the details visits
+	var sequence []string
+	for _, call := range fake.Calls() {
+		switch {
+		case call.Method == "Reload", call.Method == "ExtractLinks":
+			sequence = append(sequence, call.Method)
+		case call.Method == "Navigate" && strings.Contains(call.Args[0], "task=detalhes"):
+			sequence = append(sequence, "details "+call.Args[0][strings.LastIndex(call.Args[0], "=")+1:])
+		}
+		if len(sequence) == 4 {
+			break
+		}
+	}
+	want := []string{"ExtractLinks", "Reload", "ExtractLinks", "details W1-1"}
+	if strings.Join(sequence, ", ") != strings.Join(want, ", ") {
+		t.Errorf("calls = %v, want %v", sequence, want)
+	}
+}
+
+// waitedSelectors returns the selectors fake was asked to wait for, once each
+func waitedSelectors(fake *browsertest.FakeBrowser) []string {
+	var selectors []string
+	seen := make(map[string]bool)
+	for _, call := range fake.Calls() {
+		if call.Method == "WaitForElement" && !seen[call.Args[0]] {
+			seen[call.Args[0]] = true
+			selectors = append(selectors, call.Args[0])
+		}
+	}
+	return selectors
+}
+
+func TestProcessWaitsForResultsReadySelector(t *testing.T) {
+	const ready = "#results-rendered"
+
+	tests := []struct {
+		name     string
+		selector string
+		want     string
+	}{
+		{"default", "", ResultLinkSelector},
+		{"dedicated", ready, ready},
+	}
+
+	for _, tt := range tests {
+		fake := browsertest.NewFakeBrowser()
+		scriptSearch(fake, 2, []int{2})
+		fake.SetText(testSearchURL, ready, "")
+
+		extractor := newTestExtractor(fake, func(options *ProcessorOptions) {
+			options.ResultsReadySelector = tt.selector
+		})
+		if _, err := extractor.Process(context.Background(), "soil", testSearchURL); err != nil {
+			t.Fatalf("%s: Process() error = %v", tt.name, err)
+		}
+
+		got := waitedSelectors(fake)
+		if len(got) != 1 || got[0] != tt.want {
+			t.Errorf("%s: waited for %q, want only %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCleanCitation(t *testing.T) {
+	tests := []struct {
+		citation string
+		want     string
+	}{
+		{"", ""},
+		{
+			"SILVA, J. Soil carbon. Revista Solos, v. 3, p. 1-10, 2021.",
+			"SILVA, J. Soil carbon. Revista Solos, v. 3, p. 1-10, 2021.",
+		},
+		{
+			"\n\t\tSILVA, J.; SOUZA, M.\n\t\tSoil carbon in tropical forests.\r\n\t\tRevista Solos,  v. 3,\n  p. 1-10, 2021.\n\t",
+			"SILVA, J.; SOUZA, M. Soil carbon in tropical forests. Revista Solos, v. 3, p. 1-10, 2021.",
+		},
+	}
+	for _, tt := range tests {
+		if got := cleanCitation(tt.citation); got != tt.want {
+			t.Errorf("cleanCitation(%q) = %q, want %q", tt.citation, got, tt.want)
+		}
+	}
+}
+
+func TestExtractCitationFromDetail(t *testing.T) {
+	const page = "https://www.periodicos.capes.gov.br/detail"
+	ctx := context.Background()
+
+	fake := browsertest.NewFakeBrowser()
+	fake.Open(ctx, page)
+	extractor := newTestExtractor(fake, nil)
+	if got := extractor.extractCitationFromDetail(ctx, fake); got != "" {
+		t.Errorf("extractCitationFromDetail() = %q without a citation, want it blank", got)
+	}
+
+	fake.SetText(page, DetailCitationSelector, "SOUZA, M.\n   Solos tropicais.\n   Campinas: IAC, 2019.")
+	want := "SOUZA, M. Solos tropicais. Campinas: IAC, 2019."
+	if got := extractor.extractCitationFromDetail(ctx, fake); got != want {
+		t.Errorf("extractCitationFromDetail() = %q, want %q", got, want)
+	}
+}
+
+func TestProcessPageRange(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	scriptSearch(fake, 150, []int{1, 2, 2, 1, 1})
+
+	extractor := newTestExtractor(fake, func(options *ProcessorOptions) {
+		options.PageRangeStart = 2
+		options.PageRangeEnd = 3
+		options.MaxPages = 1 // The range wins
+	})
+	collection, err := extractor.Process(context.Background(), "soil", testSearchURL)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	var ids []string
+	for _, result := range collection.Results {
+		ids = append(ids, result.ID)
+	}
+	if got, want := strings.Join(ids, " "), "W2-1 W2-2 W3-1 W3-2"; got != want {
+		t.Errorf("Process() extracted %s, want exactly pages 2 and 3: %s", got, want)
+	}
+
+	calls := fake.Calls()
+	if calls[0].Method != "Open" || calls[0].Args[0] != testSearchURL+"&page=2" {
+		t.Errorf("first call = %v, want Open of page 2", calls[0])
+	}
+	for _, call := range calls {
+		if call.Method == "Navigate" && strings.Contains(call.Args[0], "&page=4") {
+			t.Errorf("navigated to %s, past the end of the range", call.Args[0])
+		}
+	}
+}
+
+func TestProcessFailsFastWhenUnreachable(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	scriptSearch(fake, 30, []int{1})
+	unreachable := errors.WithCode(
+		errors.NewNetworkError("cannot reach CAPES", fmt.Errorf("net::ERR_NAME_NOT_RESOLVED")),
+		errors.CodeUnreachable,
+	)
+	fake.FailOn("Open", unreachable)
+
+	extractor := newTestExtractor(fake, nil)
+	_, err := extractor.Process(context.Background(), "soil", testSearchURL)
+	if errors.Code(err) != errors.CodeUnreachable {
+		t.Fatalf("Process() = %v, want the unreachable error", err)
+	}
+	if got := fake.CallCount("Open"); got != 1 {
+		t.Errorf("Open called %d times, want no retries", got)
+	}
+}
+
+func TestProcessRecordsFailedDetailField(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	scriptSearch(fake, 2, []int{2})
+	// The second details page loads but has no year
+	fake.SetPage(detailURL("W1-2"), &browsertest.Page{
+		Texts: map[string]string{DetailJournalSelector: "Revista Solos"},
+	})
+
+	collection, err := newTestExtractor(fake, nil).Process(context.Background(), "soil", testSearchURL)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if len(collection.Errors) != 1 {
+		t.Fatalf("collection errors = %+v, want one for the missing year", collection.Errors)
+	}
+	got := collection.Errors[0]
+	if got.URL != detailURL("W1-2") || got.Field != "year" {
+		t.Errorf("extraction error = {URL %q, Field %q}, want {%q, year}", got.URL, got.Field, detailURL("W1-2"))
+	}
+	if !strings.Contains(got.Cause, "element not found: "+DetailYearSelector) {
+		t.Errorf("extraction error cause = %q, want the browser failure", got.Cause)
+	}
+	if collection.TotalResults != 2 || collection.Results[1].Year != "" {
+		t.Errorf("Process() = %d results, want both kept with the year left blank", collection.TotalResults)
+	}
+}
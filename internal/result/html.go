@@ -0,0 +1,217 @@
+package result
+
+import (
+	"context"
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/alexandreffaria/reviu/internal/errors"
+	"github.com/alexandreffaria/reviu/internal/logger"
+)
+
+// htmlDateLayout formats the search date in the report header
+const htmlDateLayout = "02/01/2006 15:04"
+
+// htmlReportTemplate renders a self-contained report; html/template escapes
+// every field value and rejects unsafe link schemes
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{if .SearchTerm}}{{.SearchTerm}} – {{end}}Resultados da busca CAPES</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Roboto, Arial, sans-serif; margin: 2rem; color: #222; }
+h1 { font-size: 1.4rem; margin-bottom: 0.2rem; }
+.meta { color: #666; margin-top: 0; }
+table { border-collapse: collapse; width: 100%; font-size: 0.9rem; }
+th, td { border: 1px solid #ddd; padding: 0.4rem 0.6rem; text-align: left; vertical-align: top; }
+th { background: #f3f3f3; position: sticky; top: 0; }
+tr:nth-child(even) td { background: #fafafa; }
+a { color: #0b5cad; }
+footer { color: #666; margin-top: 1rem; font-size: 0.85rem; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{if .SearchTerm}}Busca: {{.SearchTerm}}{{else}}Resultados da busca CAPES{{end}}</h1>
+<p class="meta">Realizada em {{.Date}}</p>
+</header>
+<table>
+<thead>
+<tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{- range .Rows}}
+<tr>{{range .}}<td>{{if .Href}}<a href="{{.Href}}" target="_blank" rel="noopener noreferrer">{{.Text}}</a>{{else}}{{.Text}}{{end}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+<footer>{{len .Rows}} {{if eq (len .Rows) 1}}resultado{{else}}resultados{{end}}</footer>
+</body>
+</html>
+`))
+
+// htmlCell is a table cell, shown as a link when Href is set
+type htmlCell struct {
+	Text string
+	Href string
+}
+
+// htmlReport is the data the report template is rendered with
+type htmlReport struct {
+	SearchTerm string
+	Date       string
+	Header     []string
+	Rows       [][]htmlCell
+}
+
+// HTMLWriter implements ResultWriter for a standalone HTML report, for
+// sharing a search with people who don't use spreadsheets
+// The document needs a footer, so results are buffered and written on Close
+type HTMLWriter struct {
+	config     ExportConfig
+	columns    columnSet
+	file       io.WriteCloser
+	log        logger.Logger
+	rows       [][]htmlCell
+	searchTerm string
+	searchDate time.Time
+}
+
+// NewHTMLWriter creates a new HTML writer
+func NewHTMLWriter(config ExportConfig, log logger.Logger) (*HTMLWriter, error) {
+	if config.FilePath == "" {
+		return nil, errors.NewConfigError("file path is required for HTML export", nil)
+	}
+
+	if log == nil {
+		log = logger.NewLogger() // Default logger
+	}
+
+	columns, err := config.columns()
+	if err != nil {
+		return nil, err
+	}
+
+	return &HTMLWriter{
+		config:  config,
+		columns: columns,
+		log:     log.WithPrefix("HTMLExport"),
+	}, nil
+}
+
+// Initialize opens the file the report will be written to
+func (w *HTMLWriter) Initialize() error {
+	file, err := openExportFile(&w.config, w.log)
+	if err != nil {
+		return err
+	}
+
+	w.file = file
+	w.log.Info("HTML export initialized: %s", w.config.FilePath)
+	return nil
+}
+
+// WriteHeader is a no-op: the table header is always part of the report
+func (w *HTMLWriter) WriteHeader() error {
+	if w.file == nil {
+		return errors.NewConfigError("HTML writer not initialized, call Initialize first", nil)
+	}
+	return nil
+}
+
+// WriteResult buffers a single search result as a table row
+func (w *HTMLWriter) WriteResult(r SearchResult) error {
+	if w.file == nil {
+		return errors.NewConfigError("HTML writer not initialized, call Initialize first", nil)
+	}
+
+	w.rows = append(w.rows, w.row(r))
+	return nil
+}
+
+// WriteResults buffers multiple results, checking for cancellation first
+func (w *HTMLWriter) WriteResults(ctx context.Context, results []SearchResult) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		if err := w.WriteResult(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCollection buffers an entire search collection and keeps its search
+// term and date for the report header
+func (w *HTMLWriter) WriteCollection(ctx context.Context, collection *SearchCollection) error {
+	if collection == nil {
+		return errors.NewConfigError("search collection cannot be nil", nil)
+	}
+
+	w.searchTerm = collection.SearchTerm
+	w.searchDate = collection.SearchDate
+
+	if err := w.WriteResults(ctx, collection.Results); err != nil {
+		return err
+	}
+
+	w.log.Info("Buffered %d search results for HTML", collection.TotalResults)
+	return nil
+}
+
+// Close renders the report and closes the file
+func (w *HTMLWriter) Close() error {
+	if w.file == nil {
+		return nil // Nothing to close
+	}
+
+	date := w.searchDate
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	writeErr := htmlReportTemplate.Execute(w.file, htmlReport{
+		SearchTerm: w.searchTerm,
+		Date:       date.Format(htmlDateLayout),
+		Header:     w.columns.header(),
+		Rows:       w.rows,
+	})
+	closeErr := w.file.Close()
+	w.file = nil
+
+	if writeErr != nil {
+		return errors.NewExternalError("error writing HTML report", writeErr)
+	}
+	if closeErr != nil {
+		return errors.NewExternalError("error closing HTML file", closeErr)
+	}
+
+	w.log.Info("HTML export completed: %s (%d rows)", w.config.FilePath, len(w.rows))
+	return nil
+}
+
+// Path returns the file the report is written to
+func (w *HTMLWriter) Path() string {
+	return w.config.FilePath
+}
+
+// row returns the cells of r; the title and link columns point to the
+// result's page
+func (w *HTMLWriter) row(r SearchResult) []htmlCell {
+	texts := w.columns.row(w.config, r)
+
+	cells := make([]htmlCell, len(texts))
+	for i, text := range texts {
+		cells[i] = htmlCell{Text: text}
+		switch w.columns[i].name {
+		case "titulo", "link":
+			cells[i].Href = r.URL
+		}
+	}
+	return cells
+}
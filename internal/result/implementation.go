@@ -2,7 +2,10 @@ package result
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/alexandreffaria/reviu/internal/browser"
@@ -16,6 +19,14 @@ type MainResultProcessor struct {
 	log       logger.Logger
 	extractor *CAPESResultExtractor
 	options   ProcessorOptions
+	summary   RunSummary
+	stats     ExportStats
+	
+	// closeErrors counts the writers of the current run that failed to close
+	closeErrors int
+	
+	// exportFiles are the files the current run exported to, one per format
+	exportFiles []string
 }
 
 // NewResultProcessor creates a new processor
@@ -45,6 +56,27 @@ func (p *MainResultProcessor) SetLogger(log logger.Logger) {
 	}
 }
 
+// SetDetailBrowserFactory sets the function used to create the browsers that
+// open detail pages in parallel, one per worker
+func (p *MainResultProcessor) SetDetailBrowserFactory(factory func() browser.Browser) {
+	p.extractor.SetDetailBrowserFactory(factory)
+}
+
+// SetProgressFunc sets the function called after every processed page
+func (p *MainResultProcessor) SetProgressFunc(progress ProgressFunc) {
+	p.extractor.SetProgressFunc(progress)
+}
+
+// Summary returns the statistics of the last ProcessAndExport run
+func (p *MainResultProcessor) Summary() RunSummary {
+	return p.summary
+}
+
+// ExportStats returns the timing and size of the last ProcessAndExport export
+func (p *MainResultProcessor) ExportStats() ExportStats {
+	return p.stats
+}
+
 // ProcessAndExport extracts results and exports them to the configured format
 func (p *MainResultProcessor) ProcessAndExport(ctx context.Context, searchParams *config.SearchParams, searchURL string) error {
 	// Create context for the entire operation
@@ -54,51 +86,184 @@ func (p *MainResultProcessor) ProcessAndExport(ctx context.Context, searchParams
 	// Start timing
 	startTime := time.Now()
 	
+	// Deferred first so it runs after the writers are closed and the export
+	// file has its final size
+	p.summary = RunSummary{}
+	p.closeErrors = 0
+	p.exportFiles = nil
+	defer p.recordExportStats(startTime)
+	
+	// An appended export skips the publications it already has
+	var existing *ExistingKeys
+	if searchParams.Append && searchParams.OutputFile != "" {
+		var err error
+		if existing, err = p.existingKeys(searchParams); err != nil {
+			return err
+		}
+	}
+	
+	// An incremental export also skips what earlier runs exported and keeps
+	// only what was published since the requested date
+	since := p.options.Since
+	if p.options.LastRunFile != "" {
+		lastRun, err := LoadLastRun(p.options.LastRunFile)
+		if err != nil {
+			return err
+		}
+		if lastRun != nil {
+			if existing == nil {
+				existing = NewExistingKeys()
+			}
+			existing.Merge(lastRun.Keys())
+			p.log.Info("Last run on %s had exported %d publications", lastRun.RunAt.Local().Format(time.DateTime), len(lastRun.IDs))
+			if p.options.SinceLastRun {
+				since = lastRun.RunAt
+			}
+		} else if p.options.SinceLastRun {
+			p.log.Warn("No earlier run recorded in %s, keeping results from any date", p.options.LastRunFile)
+		}
+	}
+	
+	// When streaming, the export is opened first and fed page by page
+	streaming := p.options.StreamToDisk && searchParams.OutputFile != ""
+	var writer ResultWriter
+	if streaming {
+		var err error
+		if writer, err = p.openWriter(searchParams); err != nil {
+			return err
+		}
+		defer p.closeWriter(writer)
+		
+		p.extractor.SetStreamWriter(writer)
+		p.extractor.SetExistingKeys(existing)
+		defer p.extractor.SetStreamWriter(nil)
+		defer p.extractor.SetExistingKeys(nil)
+		p.log.Info("Streaming results to %s as each page is extracted", writer.Path())
+	}
+	
 	// Extract results
 	p.log.Info("Starting result extraction for search: %s", searchParams.SearchTerm)
 	collection, err := p.extractor.Process(ctx, searchParams.SearchTerm, searchURL)
-	if err != nil {
+	interrupted := err != nil && collection != nil && ctx.Err() == context.Canceled
+	if interrupted {
+		// Save what was collected; the export itself must not be cancelled
+		p.log.Warn("Extraction interrupted, exporting the %d results collected so far", collection.TotalResults)
+		ctx = context.WithoutCancel(ctx)
+	} else if errors.IsErrorType(err, errors.Network) || errors.IsErrorType(err, errors.External) || errors.IsErrorType(err, errors.Configuration) {
+		return err // Keep the classification for a clear message
+	} else if err != nil {
 		return errors.NewBrowserError("failed during result extraction", err)
 	}
 	
+	p.summary = newRunSummary(collection, searchURL, time.Since(startTime))
+	p.summary.Interrupted = interrupted
+	defer func() {
+		p.summary.DurationSeconds = time.Since(startTime).Seconds()
+	}()
+	
+	// Streamed results were deduplicated and written during extraction
+	if streaming {
+		p.summary.DuplicatesRemoved = p.extractor.StreamedDuplicates()
+		p.summary.ResultsWritten = collection.TotalResults
+		p.exportFiles = writerPaths(writer)
+		p.summary.OutputFiles = append(p.summary.OutputFiles, p.exportFiles...)
+		return p.finishExport(collection, searchParams, interrupted, startTime)
+	}
+	
+	// CAPES sometimes lists the same publication on consecutive pages
+	if p.options.Dedup {
+		removed := collection.Deduplicate()
+		p.summary.DuplicatesRemoved += removed
+		if removed > 0 {
+			p.log.Info("Removed %d duplicate results (same ID or URL); %d remain", removed, collection.TotalResults)
+		}
+	}
+	
+	// Merge near-duplicate titles if requested
+	if p.options.TitleDedupDistance > 0 {
+		removed := collection.DeduplicateFuzzyTitles(p.options.TitleDedupDistance)
+		p.summary.DuplicatesRemoved += removed
+		p.log.Info("Removed %d near-duplicate titles (distance <= %d)", removed, p.options.TitleDedupDistance)
+	}
+	
+	// Leave out what an earlier run already appended to the file
+	if existing != nil {
+		removed := collection.RemoveExisting(existing)
+		p.summary.DuplicatesRemoved += removed
+		if removed > 0 {
+			p.log.Info("Skipped %d results already exported to %s; %d remain", removed, searchParams.OutputFile, collection.TotalResults)
+		}
+	}
+	
+	// Keep only what was published since the requested date
+	if !since.IsZero() {
+		removed := collection.FilterSince(since)
+		p.summary.FilteredOut += removed
+		p.log.Info("Filtered out %d results published before %d; %d remain", removed, since.Year(), collection.TotalResults)
+	}
+	
+	// Keep only the publications of the requested authors
+	if len(p.options.AuthorFilter) > 0 {
+		removed := collection.FilterByAuthor(p.options.AuthorFilter)
+		p.summary.FilteredOut += removed
+		p.log.Info("Filtered out %d results without any of the authors %q; %d remain",
+			removed, p.options.AuthorFilter, collection.TotalResults)
+	}
+	
+	// Protect an existing export from being replaced by a bad run
+	if minResults := minResultsToExport(searchParams); collection.TotalResults < minResults {
+		p.log.Warn("Run returned %d results (minimum %d), not exporting", collection.TotalResults, minResults)
+		return errors.NewEmptyResultError(
+			fmt.Sprintf("run returned %d results, fewer than the minimum of %d; %s was left untouched",
+				collection.TotalResults, minResults, searchParams.OutputFile),
+			nil,
+		)
+	}
+	
+	// An empty collection is a valid outcome, not a failure
+	if collection.TotalResults == 0 {
+		p.log.Info("Search succeeded but returned no matches; exporting headers and summary only")
+	}
+	
 	// If export is enabled, export the results
 	if searchParams.OutputFile != "" {
 		p.log.Info("Exporting %d results to %s", collection.TotalResults, searchParams.OutputFile)
 		
-		// Create export configuration
-		exportConfig := ExportConfig{
-			FilePath:          searchParams.OutputFile,
-			Format:            FormatCSV,
-			Delimiter:         ',',
-			IncludeHeader:     true, // We'll always include headers for now
-			CharacterEncoding: "utf-8",
-		}
-		
-		// Create writer
-		writer, err := NewWriter(exportConfig, p.log)
+		writer, err := p.openWriter(searchParams)
 		if err != nil {
-			return errors.NewConfigError("failed to create export writer", err)
-		}
-		
-		// Initialize writer
-		if err := writer.Initialize(); err != nil {
-			return errors.NewConfigError("failed to initialize export writer", err)
+			return err
 		}
 		
 		// Ensure writer is closed when done
-		defer func() {
-			if err := writer.Close(); err != nil {
-				p.log.Error("Failed to close export writer: %v", err)
-			}
-		}()
+		defer p.closeWriter(writer)
 		
 		// Export collection
-		if err := writer.WriteCollection(collection); err != nil {
+		if err := writer.WriteCollection(ctx, collection); err != nil {
 			return errors.NewExternalError("failed to export results", err)
 		}
-		
+		p.summary.ResultsWritten = collection.TotalResults
+		p.exportFiles = writerPaths(writer)
+		p.summary.OutputFiles = append(p.summary.OutputFiles, p.exportFiles...)
+	}
+	
+	// Record the run so the next -since last continues from here; an
+	// interrupted run may have missed results, so it isn't recorded
+	if p.options.LastRunFile != "" && !interrupted {
+		if err := saveLastRun(p.options.LastRunFile, searchURL, startTime, existing, collection); err != nil {
+			p.log.Warn("Failed to record run: %v", err)
+		}
+	}
+	
+	return p.finishExport(collection, searchParams, interrupted, startTime)
+}
+
+// finishExport writes the search summary next to the export and removes the
+// checkpoint of a completed run
+func (p *MainResultProcessor) finishExport(collection *SearchCollection, searchParams *config.SearchParams, interrupted bool, startTime time.Time) error {
+	if searchParams.OutputFile != "" {
 		// Generate a path for the summary file
-		summaryPath := getSummaryFilePath(searchParams.OutputFile)
+		// The summary is small and meant to be appended to, so it's never compressed
+		summaryPath := getSummaryFilePath(strings.TrimSuffix(searchParams.OutputFile, gzipExtension))
 		
 		// Write or append search summary to CSV
 		if err := WriteSummaryToCSV(collection, searchParams, summaryPath, p.log); err != nil {
@@ -106,6 +271,15 @@ func (p *MainResultProcessor) ProcessAndExport(ctx context.Context, searchParams
 			// We continue even if summary fails - it's not critical
 		} else {
 			p.log.Info("Search summary exported to %s", summaryPath)
+			p.summary.OutputFiles = append(p.summary.OutputFiles, summaryPath)
+		}
+		
+		// The export is complete, so there is nothing left to resume; an
+		// interrupted run keeps its checkpoint for -resume
+		if p.options.CheckpointFile != "" && !interrupted {
+			if err := RemoveCheckpoint(p.options.CheckpointFile); err != nil {
+				p.log.Warn("Failed to remove checkpoint: %v", err)
+			}
 		}
 		
 		// Report success
@@ -117,26 +291,264 @@ func (p *MainResultProcessor) ProcessAndExport(ctx context.Context, searchParams
 	return nil
 }
 
+// recordExportStats fills the export statistics of the run started at
+// startTime from its summary
+func (p *MainResultProcessor) recordExportStats(startTime time.Time) {
+	endTime := time.Now()
+	p.stats = ExportStats{
+		StartTime:      startTime,
+		EndTime:        endTime,
+		Duration:       endTime.Sub(startTime),
+		TotalResults:   p.summary.ResultsCollected,
+		ResultsWritten: p.summary.ResultsWritten,
+		ErrorCount:     len(p.summary.ExtractionErrors) + p.closeErrors,
+	}
+	
+	// The size of a multi-format export adds up all of its files
+	for _, path := range p.exportFiles {
+		file := ExportedFile{Path: path}
+		if info, err := os.Stat(path); err == nil {
+			file.Bytes = info.Size()
+		}
+		p.stats.Files = append(p.stats.Files, file)
+		p.stats.BytesWritten += file.Bytes
+	}
+	if len(p.stats.Files) > 0 {
+		p.stats.FilePath = p.stats.Files[0].Path
+	}
+	
+	if p.stats.ErrorCount > 0 {
+		p.log.Warn("Export finished with %d errors, see the messages above", p.stats.ErrorCount)
+	}
+	p.log.Debug("%s", p.stats.String())
+}
+
+// openWriter creates and initializes the writer for the export configured in
+// searchParams
+func (p *MainResultProcessor) openWriter(searchParams *config.SearchParams) (ResultWriter, error) {
+	// Without detail pages only the results page columns have data, so they
+	// are the default; columns chosen explicitly are kept, but stay empty
+	columns := searchParams.Columns
+	if searchParams.SkipDetails {
+		if len(columns) == 0 {
+			columns = ListPageColumns
+		} else if empty := detailOnlyColumns(columns); len(empty) > 0 {
+			p.log.Warn("Columns %s will be empty, -no-details skips the detail pages they come from",
+				strings.Join(empty, ", "))
+		}
+	}
+	
+	// Create export configuration
+	exportConfig := ExportConfig{
+		FilePath:          searchParams.OutputFile,
+		Delimiter:         searchParams.CSVDelimiter,
+		QuoteAll:          searchParams.QuoteAll,
+		IncludeHeader:     true, // We'll always include headers for now
+		CharacterEncoding: "utf-8",
+		Compress:          searchParams.Compress,
+		WriteBOM:          searchParams.WriteBOM,
+		Columns:           columns,
+		OnExisting:        ExistingFileMode(searchParams.OnExisting),
+		AuthorSeparator:   searchParams.AuthorSeparator,
+		PublicationTypes:  searchParams.PublicationTypes,
+		Append:            searchParams.Append,
+	}
+	
+	// Create a writer per format, all fed the same results
+	formats := exportFormats(searchParams)
+	writers := make([]ResultWriter, 0, len(formats))
+	for _, format := range formats {
+		exportConfig.Format = format
+		formatWriter, err := NewWriter(exportConfig, p.log)
+		if err != nil {
+			return nil, errors.NewConfigError("failed to create export writer", err)
+		}
+		writers = append(writers, formatWriter)
+	}
+	
+	var writer ResultWriter = writers[0]
+	if len(writers) > 1 {
+		writer = NewMultiWriter(writers...)
+	}
+	
+	// Initialize writer
+	if err := writer.Initialize(); err != nil {
+		return nil, errors.NewConfigError("failed to initialize export writer", err)
+	}
+	
+	return writer, nil
+}
+
+// existingKeys reads the IDs and DOIs already in the file an appended export
+// will be added to
+func (p *MainResultProcessor) existingKeys(searchParams *config.SearchParams) (*ExistingKeys, error) {
+	path := ExportFilePath(ExportConfig{
+		FilePath: searchParams.OutputFile,
+		Format:   FormatCSV, // Only CSV exports can be appended to
+	})
+
+	keys, err := ReadExistingKeys(path, searchParams.CSVDelimiter)
+	if err != nil {
+		return nil, err
+	}
+	if keys.Len() > 0 {
+		p.log.Info("Appending to %s, which already identifies %d publications", path, keys.Len())
+	}
+	return keys, nil
+}
+
+// closeWriter closes writer, logging any error since the export has
+// already been reported by then
+func (p *MainResultProcessor) closeWriter(writer ResultWriter) {
+	if err := writer.Close(); err != nil {
+		p.closeErrors++
+		p.log.Error("Failed to close export writer: %v", err)
+	}
+}
+
 // ProcessSearchResults is a convenience method that handles the entire process
-func (p *MainResultProcessor) ProcessSearchResults(searchParams *config.SearchParams, searchURL string) error {
-	// Create a background context
-	ctx := context.Background()
+// Cancelling ctx stops the extraction at the next page and exports the
+// results collected until then
+func (p *MainResultProcessor) ProcessSearchResults(ctx context.Context, searchParams *config.SearchParams, searchURL string) error {
+	// Set options
+	p.SetOptions(processorOptions(searchParams))
 	
-	// Create processor options from search params
+	// Process and export
+	return p.ProcessAndExport(ctx, searchParams, searchURL)
+}
+
+// processorOptions creates the processor options for a run from search params
+func processorOptions(searchParams *config.SearchParams) ProcessorOptions {
 	options := ProcessorOptions{
 		MaxPages:          searchParams.MaxPages,
+		PageRangeStart:    searchParams.PageRangeStart,
+		PageRangeEnd:      searchParams.PageRangeEnd,
+		MaxResults:        searchParams.MaxResults,
 		Timeout:           600, // 10 minutes default
 		RetryAttempts:     3,
+		Retry: RetryOptions{
+			MaxAttempts:  3,
+			InitialDelay: int(searchParams.RetryInitialDelay.Milliseconds()),
+			MaxDelay:     int(searchParams.RetryMaxDelay.Milliseconds()),
+			Factor:       2.0,
+		},
 		PageTimeout:       30,  // 30 seconds per page
 		NavigationTimeout: 30,  // 30 seconds for navigation
 		PageDelay:         searchParams.PageDelay, // Use the delay specified in search params
+		DetailDelay:       searchParams.DetailDelay,
+		RetryOnEmptyPage:  searchParams.RetryOnEmptyPage,
+		ReuseBrowser:      true,
+		Resume:            searchParams.Resume,
+		ScreenshotOnEmpty: searchParams.ScreenshotOnEmpty,
+		AbortOnBlock:      searchParams.AbortOnBlock,
+		PauseFile:         searchParams.PauseFile,
+		Deadline:          searchParams.Deadline,
+		ThrottleFactor:    searchParams.ThrottleFactor,
+		ThrottleWindow:    3,
+		ThrottleMaxDelay:  searchParams.ThrottleMaxDelay,
+
+		ExtractAbstracts:        searchParams.ExtractAbstracts,
+		AbstractsOpenAccessOnly: searchParams.AbstractsOpenAccessOnly,
+		NormalizeAuthors:        searchParams.NormalizeAuthors,
+		SkipDetails:             searchParams.SkipDetails,
+
+		Dedup:              true,
+		TitleDedupDistance: searchParams.TitleDedupDistance,
+		AuthorFilter:       searchParams.AuthorFilter,
+		DetailConcurrency:  DefaultDetailConcurrency,
+		StreamToDisk:       searchParams.StreamToDisk,
+
+		Since:        searchParams.Since,
+		SinceLastRun: searchParams.SinceLastRun,
 	}
 	
-	// Set options
-	p.SetOptions(options)
+	// Record incremental runs next to the output file for the next -since
+	if searchParams.OutputFile != "" && searchParams.SinceSpec != "" {
+		options.LastRunFile = LastRunPath(searchParams.OutputFile)
+	}
 	
-	// Process and export
-	return p.ProcessAndExport(ctx, searchParams, searchURL)
+	// Save progress next to the output file so an interrupted run can resume
+	// A streamed export has no results in memory to save, so it can't resume
+	if searchParams.OutputFile != "" && !searchParams.StreamToDisk {
+		options.CheckpointFile = CheckpointPath(searchParams.OutputFile)
+	}
+	
+	// A deadline replaces the fixed overall timeout, which would otherwise
+	// cut long unattended runs short without exporting anything
+	if !options.Deadline.IsZero() {
+		options.Timeout = 0
+	}
+	
+	return options
+}
+
+// DumpPageURLs writes the URL of every results page to searchParams.URLListFile,
+// one per line, without extracting any results
+func (p *MainResultProcessor) DumpPageURLs(ctx context.Context, searchParams *config.SearchParams, searchURL string) (int, error) {
+	// Opening the search to read the page count needs the same retries,
+	// delays and timeouts as a full run
+	p.SetOptions(processorOptions(searchParams))
+
+	urls, err := p.extractor.PageURLs(ctx, searchURL, searchParams.Pages)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := WriteURLList(searchParams.URLListFile, urls); err != nil {
+		return 0, err
+	}
+
+	p.log.Info("Wrote %d page URLs to %s", len(urls), searchParams.URLListFile)
+	return len(urls), nil
+}
+
+// CountResults returns how many results the search reports and how many pages
+// they span, without extracting them
+func (p *MainResultProcessor) CountResults(ctx context.Context, searchURL string) (int, int, error) {
+	return p.extractor.CountResults(ctx, searchURL)
+}
+
+// Preflight runs the preflight checks against the search URL and closes the
+// browser afterwards, so the extraction starts from a fresh instance
+func (p *MainResultProcessor) Preflight(ctx context.Context, searchURL string) PreflightReport {
+	report := p.extractor.Preflight(ctx, searchURL)
+	if err := p.extractor.browser.Close(); err != nil {
+		p.log.Warn("Error closing browser after preflight: %v", err)
+	}
+	return report
+}
+
+// exportFormats returns the formats of the export, parsed from -format by
+// validation, defaulting to CSV
+func exportFormats(searchParams *config.SearchParams) []ExportFormat {
+	formats := make([]ExportFormat, 0, len(searchParams.ExportFormats))
+	for _, format := range searchParams.ExportFormats {
+		formats = append(formats, ExportFormat(format))
+	}
+	if len(formats) == 0 {
+		formats = append(formats, exportFormat(searchParams.ExportFormat))
+	}
+	return formats
+}
+
+// exportFormat converts the -format value, defaulting to CSV
+func exportFormat(format string) ExportFormat {
+	if format == "" {
+		return FormatCSV
+	}
+	return ExportFormat(format)
+}
+
+// minResultsToExport returns how many results a run needs before its export
+// may be written, or 0 when any result count is exported
+func minResultsToExport(searchParams *config.SearchParams) int {
+	if searchParams.MinResults > 0 {
+		return searchParams.MinResults
+	}
+	if searchParams.NoExportOnEmpty {
+		return 1
+	}
+	return 0
 }
 
 // getSummaryFilePath derives a summary file path from the main output file path
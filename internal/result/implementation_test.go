@@ -0,0 +1,226 @@
+package result
+
+import (
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexandreffaria/reviu/internal/browser/browsertest"
+	"github.com/alexandreffaria/reviu/internal/config"
+	"github.com/alexandreffaria/reviu/internal/errors"
+)
+
+func TestDumpPageURLs(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	fake.SetText(testSearchURL, ResultCountSelector, "75 resultados")
+
+	params := config.NewSearchParams()
+	params.URLListFile = filepath.Join(t.TempDir(), "urls.txt")
+	params.RetryInitialDelay = 250 * time.Millisecond
+
+	processor := NewResultProcessor(fake, quietLogger())
+	count, err := processor.DumpPageURLs(context.Background(), params, testSearchURL)
+	if err != nil {
+		t.Fatalf("DumpPageURLs() error = %v", err)
+	}
+
+	want := []string{testSearchURL, testSearchURL + "&page=2", testSearchURL + "&page=3"}
+	data, err := os.ReadFile(params.URLListFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Fields(string(data)); count != 3 || strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("DumpPageURLs() wrote %d URLs %v, want %v", count, got, want)
+	}
+
+	// The browser runs with the same settings as a full extraction
+	if got := processor.extractor.options.Retry.InitialDelay; got != 250 {
+		t.Errorf("retry initial delay = %dms, want the 250ms from the search params", got)
+	}
+	if got := processor.extractor.options.PageDelay; got != params.PageDelay {
+		t.Errorf("page delay = %v, want %v", got, params.PageDelay)
+	}
+}
+
+func TestDumpPageURLsWithPageCount(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+
+	params := config.NewSearchParams()
+	params.URLListFile = filepath.Join(t.TempDir(), "urls.txt")
+	params.Pages = 5
+	params.PageRangeStart = 2
+	params.PageRangeEnd = 3
+
+	processor := NewResultProcessor(fake, quietLogger())
+	if _, err := processor.DumpPageURLs(context.Background(), params, testSearchURL); err != nil {
+		t.Fatalf("DumpPageURLs() error = %v", err)
+	}
+
+	want := testSearchURL + "&page=2\n" + testSearchURL + "&page=3\n"
+	if data, _ := os.ReadFile(params.URLListFile); string(data) != want {
+		t.Errorf("DumpPageURLs() wrote %q, want %q", data, want)
+	}
+	if got := fake.CallCount("Open"); got != 0 {
+		t.Errorf("Open called %d times, want none with -pages set", got)
+	}
+}
+
+// readCSV returns the records of the CSV file at path
+func readCSV(t *testing.T, path string) [][]string {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
+	return records
+}
+
+func TestProcessAndExportEmptySearch(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	fake.SetText(testSearchURL, ResultCountSelector, "0 resultados")
+
+	dir := t.TempDir()
+	params := config.NewSearchParams()
+	params.SearchTerm = "soil"
+	params.OutputFile = filepath.Join(dir, "results.csv")
+	params.Locale = "pt"
+
+	processor := NewResultProcessor(fake, quietLogger())
+	processor.SetOptions(testOptions())
+	if err := processor.ProcessAndExport(context.Background(), params, testSearchURL); err != nil {
+		t.Fatalf("ProcessAndExport() error = %v, want an empty search to succeed", err)
+	}
+
+	records := readCSV(t, params.OutputFile)
+	if len(records) != 1 || strings.Join(records[0], ",") != strings.Join(CSVHeader, ",") {
+		t.Errorf("export = %v, want only the header %v", records, CSVHeader)
+	}
+
+	summary := readCSV(t, filepath.Join(dir, "results_summary.csv"))
+	if len(summary) != 2 {
+		t.Fatalf("summary = %v, want a header and one row", summary)
+	}
+	if count := summary[1][4]; count != "0 resultados" {
+		t.Errorf("summary count = %q, want %q", count, "0 resultados")
+	}
+}
+
+func TestProcessAndExportEmptyKeepsExistingFile(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	fake.SetText(testSearchURL, ResultCountSelector, "0 resultados")
+
+	dir := t.TempDir()
+	output := existingFile(t, dir, "results.csv")
+	params := config.NewSearchParams()
+	params.SearchTerm = "soil"
+	params.OutputFile = output
+	params.NoExportOnEmpty = true
+
+	processor := NewResultProcessor(fake, quietLogger())
+	processor.SetOptions(testOptions())
+	err := processor.ProcessAndExport(context.Background(), params, testSearchURL)
+	if !errors.IsErrorType(err, errors.EmptyResult) {
+		t.Fatalf("ProcessAndExport() = %v, want an empty result error", err)
+	}
+
+	if got := fileContent(output); got != "old" {
+		t.Errorf("output file = %q, want the existing content left untouched", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "results_summary.csv")); !os.IsNotExist(err) {
+		t.Errorf("summary file written for an empty run (stat error %v)", err)
+	}
+}
+
+// deadlineBrowser is a fake that holds the navigation to one URL until a
+// deadline has passed, so the deadline is hit at a known point of the run
+type deadlineBrowser struct {
+	*browsertest.FakeBrowser
+	slowURL  string
+	deadline time.Time
+}
+
+func (b *deadlineBrowser) Navigate(ctx context.Context, url string) error {
+	if url == b.slowURL {
+		time.Sleep(time.Until(b.deadline) + 10*time.Millisecond)
+	}
+	return b.FakeBrowser.Navigate(ctx, url)
+}
+
+func TestProcessAndExportStopsAtDeadline(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	scriptSearch(fake, 90, []int{2, 2, 2})
+	deadline := time.Now().Add(100 * time.Millisecond)
+	slow := &deadlineBrowser{FakeBrowser: fake, slowURL: detailURL("W1-2"), deadline: deadline}
+
+	params := config.NewSearchParams()
+	params.SearchTerm = "soil"
+	params.OutputFile = filepath.Join(t.TempDir(), "results.csv")
+	params.Locale = "pt"
+
+	processor := NewResultProcessor(slow, quietLogger())
+	options := testOptions()
+	options.Deadline = deadline
+	processor.SetOptions(options)
+	if err := processor.ProcessAndExport(context.Background(), params, testSearchURL); err != nil {
+		t.Fatalf("ProcessAndExport() error = %v, want the deadline to end the run successfully", err)
+	}
+
+	// The page under way when the deadline passed is finished, the rest skipped
+	for _, call := range fake.Calls() {
+		if call.Method == "Navigate" && strings.Contains(call.Args[0], "&page=") {
+			t.Errorf("navigated to %s after the deadline", call.Args[0])
+		}
+	}
+	records := readCSV(t, params.OutputFile)
+	if len(records) != 3 {
+		t.Errorf("export = %v, want a header and the 2 results of page 1", records)
+	}
+	summary := readCSV(t, strings.TrimSuffix(params.OutputFile, ".csv")+"_summary.csv")
+	if len(summary) != 2 || summary[1][4] != "2" {
+		t.Errorf("summary = %v, want one row counting 2 results", summary)
+	}
+}
+
+func TestProcessAndExportRecordsExtractionErrors(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	scriptSearch(fake, 2, []int{2})
+	// The second details page loads but has no year
+	fake.SetPage(detailURL("W1-2"), &browsertest.Page{
+		Texts: map[string]string{DetailJournalSelector: "Revista Solos"},
+	})
+
+	params := config.NewSearchParams()
+	params.SearchTerm = "soil"
+	params.OutputFile = filepath.Join(t.TempDir(), "results.csv")
+
+	processor := NewResultProcessor(fake, quietLogger())
+	processor.SetOptions(testOptions())
+	if err := processor.ProcessAndExport(context.Background(), params, testSearchURL); err != nil {
+		t.Fatalf("ProcessAndExport() error = %v", err)
+	}
+
+	errs := processor.Summary().ExtractionErrors
+	if len(errs) != 1 {
+		t.Fatalf("summary extraction errors = %+v, want one for the missing year", errs)
+	}
+	got := errs[0]
+	if got.URL != detailURL("W1-2") || got.Field != "year" {
+		t.Errorf("extraction error = {URL %q, Field %q}, want {%q, year}", got.URL, got.Field, detailURL("W1-2"))
+	}
+	if !strings.Contains(got.Cause, "element not found: "+DetailYearSelector) {
+		t.Errorf("extraction error cause = %q, want the browser failure", got.Cause)
+	}
+	if summary := processor.Summary(); summary.ResultsCollected != 2 {
+		t.Errorf("collected %d results, want the result kept despite the failed field", summary.ResultsCollected)
+	}
+}
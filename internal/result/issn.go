@@ -0,0 +1,46 @@
+package result
+
+import (
+	"regexp"
+	"strings"
+)
+
+// issnPattern matches an ISSN with or without its hyphen; CAPES sometimes
+// shows the print and electronic ISSNs together, so only the first is taken
+var issnPattern = regexp.MustCompile(`(?i)\b(\d{4})-?(\d{3}[\dx])\b`)
+
+// NormalizeISSN extracts the first ISSN in s in its "XXXX-XXXX" form, with
+// an uppercase X check digit, or returns an empty string when there is none
+func NormalizeISSN(s string) string {
+	match := issnPattern.FindStringSubmatch(s)
+	if match == nil {
+		return ""
+	}
+	return match[1] + "-" + strings.ToUpper(match[2])
+}
+
+// ValidISSN reports whether the check digit of a normalized ISSN matches its
+// first seven digits
+func ValidISSN(issn string) bool {
+	digits := strings.ReplaceAll(issn, "-", "")
+	if len(digits) != 8 {
+		return false
+	}
+
+	// The digits are weighted 8 down to 2, and the check digit brings the
+	// sum to a multiple of 11, with X standing for 10
+	sum := 0
+	for i, r := range digits[:7] {
+		if r < '0' || r > '9' {
+			return false
+		}
+		sum += int(r-'0') * (8 - i)
+	}
+
+	check := (11 - sum%11) % 11
+	last := digits[7]
+	if check == 10 {
+		return last == 'X'
+	}
+	return last == byte('0'+check)
+}
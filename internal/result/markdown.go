@@ -0,0 +1,178 @@
+package result
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/alexandreffaria/reviu/internal/errors"
+	"github.com/alexandreffaria/reviu/internal/logger"
+)
+
+// markdownEscaper escapes the characters that would break a table cell
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`|`, `\|`,
+)
+
+// MarkdownWriter implements ResultWriter for GitHub-flavored Markdown tables,
+// ready to paste into review notes
+type MarkdownWriter struct {
+	config        ExportConfig
+	columns       columnSet
+	file          io.WriteCloser
+	writer        *bufio.Writer
+	log           logger.Logger
+	rowCount      int
+	headerWritten bool
+}
+
+// NewMarkdownWriter creates a new Markdown writer
+func NewMarkdownWriter(config ExportConfig, log logger.Logger) (*MarkdownWriter, error) {
+	if config.FilePath == "" {
+		return nil, errors.NewConfigError("file path is required for Markdown export", nil)
+	}
+
+	if log == nil {
+		log = logger.NewLogger() // Default logger
+	}
+
+	columns, err := config.columns()
+	if err != nil {
+		return nil, err
+	}
+
+	return &MarkdownWriter{
+		config:  config,
+		columns: columns,
+		log:     log.WithPrefix("MarkdownExport"),
+	}, nil
+}
+
+// Initialize opens the file and writes the table header
+// A Markdown table can't exist without its header, so it is always written
+func (w *MarkdownWriter) Initialize() error {
+	file, err := openExportFile(&w.config, w.log)
+	if err != nil {
+		return err
+	}
+
+	w.file = file
+	w.writer = bufio.NewWriter(file)
+	w.log.Info("Markdown export initialized: %s", w.config.FilePath)
+
+	return w.WriteHeader()
+}
+
+// WriteHeader writes the header row and the separator row below it
+func (w *MarkdownWriter) WriteHeader() error {
+	if w.writer == nil {
+		return errors.NewConfigError("Markdown writer not initialized, call Initialize first", nil)
+	}
+
+	if w.headerWritten {
+		return nil // Header already written
+	}
+
+	header := w.columns.header()
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+
+	if err := w.writeRow(header); err != nil {
+		return errors.NewExternalError("failed to write Markdown header", err)
+	}
+	if err := w.writeRow(separator); err != nil {
+		return errors.NewExternalError("failed to write Markdown header", err)
+	}
+
+	w.headerWritten = true
+	return nil
+}
+
+// WriteResult writes a single search result as a table row
+func (w *MarkdownWriter) WriteResult(r SearchResult) error {
+	if w.writer == nil {
+		return errors.NewConfigError("Markdown writer not initialized, call Initialize first", nil)
+	}
+
+	if err := w.writeRow(w.columns.row(w.config, r)); err != nil {
+		return errors.NewExternalError("failed to write Markdown row", err)
+	}
+
+	w.rowCount++
+	return nil
+}
+
+// WriteResults writes multiple results, checking for cancellation between rows
+func (w *MarkdownWriter) WriteResults(ctx context.Context, results []SearchResult) error {
+	for _, r := range results {
+		if err := ctx.Err(); err != nil {
+			w.writer.Flush()
+			w.log.Warn("Markdown export cancelled after %d rows", w.rowCount)
+			return err
+		}
+
+		if err := w.WriteResult(r); err != nil {
+			return err
+		}
+	}
+
+	return w.writer.Flush()
+}
+
+// WriteCollection writes an entire search collection
+func (w *MarkdownWriter) WriteCollection(ctx context.Context, collection *SearchCollection) error {
+	if collection == nil {
+		return errors.NewConfigError("search collection cannot be nil", nil)
+	}
+
+	if err := w.WriteResults(ctx, collection.Results); err != nil {
+		return err
+	}
+
+	w.log.Info("Wrote %d search results to Markdown", collection.TotalResults)
+	return nil
+}
+
+// Close flushes and closes the file
+func (w *MarkdownWriter) Close() error {
+	if w.writer == nil {
+		return nil // Nothing to close
+	}
+
+	if err := w.writer.Flush(); err != nil {
+		return errors.NewExternalError("error flushing Markdown data", err)
+	}
+
+	if err := w.file.Close(); err != nil {
+		return errors.NewExternalError("error closing Markdown file", err)
+	}
+
+	w.log.Info("Markdown export completed: %s (%d rows)", w.config.FilePath, w.rowCount)
+	return nil
+}
+
+// Path returns the file the table is written to
+func (w *MarkdownWriter) Path() string {
+	return w.config.FilePath
+}
+
+// writeRow writes cells as one table row
+func (w *MarkdownWriter) writeRow(cells []string) error {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = escapeMarkdownCell(cell)
+	}
+
+	_, err := w.writer.WriteString("| " + strings.Join(escaped, " | ") + " |\n")
+	return err
+}
+
+// escapeMarkdownCell keeps cell on a single line and escapes pipes, which
+// would otherwise start a new column
+func escapeMarkdownCell(cell string) string {
+	return markdownEscaper.Replace(strings.Join(strings.Fields(cell), " "))
+}
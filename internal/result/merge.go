@@ -0,0 +1,100 @@
+package result
+
+import (
+	"fmt"
+)
+
+// ProvenanceSeparator joins the entries of the "Fontes" column
+const ProvenanceSeparator = "; "
+
+// MergeCollections combines the collections of several searches into a master
+// collection where each publication appears once. Results are matched by DOI,
+// then by CAPES document ID; results with neither are kept as they are. The
+// first occurrence of a publication is kept and its Provenance lists every
+// search that surfaced it, as "term (database)"
+func MergeCollections(collections ...*SearchCollection) *SearchCollection {
+	merged := NewSearchCollection("")
+	all := NewSearchCollection("")
+
+	for _, collection := range collections {
+		if collection == nil {
+			continue
+		}
+
+		if merged.SearchTerm == "" {
+			merged.SearchTerm = collection.SearchTerm
+		} else if collection.SearchTerm != "" {
+			merged.SearchTerm += ProvenanceSeparator + collection.SearchTerm
+		}
+		merged.TotalFound += collection.TotalFound
+		merged.TotalPages += collection.TotalPages
+		merged.Errors = append(merged.Errors, collection.Errors...)
+
+		for _, result := range collection.Results {
+			label := provenanceLabel(collection, result)
+			result.Provenance = appendUnique(append([]string(nil), result.Provenance...), label)
+			all.Results = append(all.Results, result)
+		}
+	}
+
+	// Every result hands its sources to the first occurrence of its
+	// publication, which comes earlier and is the one kept
+	byID, byDOI := all.IndexByID(), all.IndexByDOI()
+	for i := range all.Results {
+		result := &all.Results[i]
+		first := firstOccurrence(result, byID, byDOI)
+		if first == result {
+			continue
+		}
+		for _, label := range result.Provenance {
+			first.Provenance = appendUnique(first.Provenance, label)
+		}
+	}
+	for i := range all.Results {
+		if firstOccurrence(&all.Results[i], byID, byDOI) == &all.Results[i] {
+			merged.Results = append(merged.Results, all.Results[i])
+		}
+	}
+
+	merged.TotalResults = len(merged.Results)
+	return merged
+}
+
+// firstOccurrence returns the earliest result of the same publication as
+// result, which is result itself when it has no earlier match. A result
+// without a DOI is matched by ID, following that result to its DOI if it has one
+func firstOccurrence(result *SearchResult, byID, byDOI map[string]*SearchResult) *SearchResult {
+	if doi := NormalizeDOI(result.DOI); doi != "" {
+		return byDOI[doi]
+	}
+	if result.ID == "" {
+		return result
+	}
+
+	first := byID[result.ID]
+	if doi := NormalizeDOI(first.DOI); doi != "" {
+		return byDOI[doi]
+	}
+	return first
+}
+
+// provenanceLabel describes the search a result came from
+func provenanceLabel(collection *SearchCollection, result SearchResult) string {
+	if result.Source == "" {
+		return collection.SearchTerm
+	}
+	return fmt.Sprintf("%s (%s)", collection.SearchTerm, result.Source)
+}
+
+// appendUnique appends value to values unless it is empty or already present
+func appendUnique(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
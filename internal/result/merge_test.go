@@ -0,0 +1,116 @@
+package result
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// searchCollection returns a collection of results for term
+func searchCollection(term string, results ...SearchResult) *SearchCollection {
+	collection := NewSearchCollection(term)
+	collection.Results = results
+	collection.TotalResults = len(results)
+	return collection
+}
+
+func TestMergeCollectionsAccumulatesSources(t *testing.T) {
+	soil := searchCollection("soil carbon",
+		SearchResult{Title: "A", DOI: "10.1000/ABC", Source: "CAPES"},
+		SearchResult{Title: "B", ID: "W2", Source: "CAPES"},
+		SearchResult{Title: "Untitled"},
+	)
+	solo := searchCollection("carbono do solo",
+		SearchResult{Title: "A again", DOI: "https://doi.org/10.1000/abc", Source: "CAPES"},
+		SearchResult{Title: "B again", ID: "W2", Source: "CAPES"},
+		SearchResult{Title: "C", DOI: "10.1000/xyz", Source: "CAPES"},
+	)
+	scopus := searchCollection("soil carbon",
+		SearchResult{Title: "A from Scopus", DOI: "10.1000/abc", Source: "Scopus"},
+		SearchResult{Title: "A repeated", DOI: "10.1000/abc", Source: "Scopus"},
+	)
+
+	merged := MergeCollections(soil, nil, solo, scopus)
+
+	want := []struct {
+		title   string
+		sources []string
+	}{
+		{"A", []string{"soil carbon (CAPES)", "carbono do solo (CAPES)", "soil carbon (Scopus)"}},
+		{"B", []string{"soil carbon (CAPES)", "carbono do solo (CAPES)"}},
+		{"Untitled", []string{"soil carbon"}},
+		{"C", []string{"carbono do solo (CAPES)"}},
+	}
+	if merged.TotalResults != len(want) || len(merged.Results) != len(want) {
+		t.Fatalf("merged %d results %q, want %d", merged.TotalResults, resultTitles(merged.Results), len(want))
+	}
+	for i, w := range want {
+		result := merged.Results[i]
+		if result.Title != w.title || !reflect.DeepEqual(result.Provenance, w.sources) {
+			t.Errorf("result %d = %q from %q, want %q from %q", i, result.Title, result.Provenance, w.title, w.sources)
+		}
+	}
+
+	// The inputs are left as they were
+	if soil.Results[0].Provenance != nil {
+		t.Errorf("input provenance = %q, want it untouched", soil.Results[0].Provenance)
+	}
+}
+
+func TestMergedExportSourcesColumn(t *testing.T) {
+	merged := MergeCollections(
+		searchCollection("soil carbon", SearchResult{Title: "A", DOI: "10.1000/abc", Source: "CAPES"}),
+		searchCollection("carbono do solo", SearchResult{Title: "A", DOI: "10.1000/abc", Source: "CAPES"}),
+	)
+
+	path := filepath.Join(t.TempDir(), "master.csv")
+	writer, err := NewCSVWriter(ExportConfig{
+		FilePath:          path,
+		Format:            FormatCSV,
+		IncludeHeader:     true,
+		IncludeProvenance: true,
+	}, quietLogger())
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+	if err := writer.Initialize(); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if err := writer.WriteCollection(context.Background(), merged); err != nil {
+		t.Fatalf("WriteCollection() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	records := readCSV(t, path)
+	header, row := records[0], records[1]
+	if header[len(header)-1] != ProvenanceHeader {
+		t.Fatalf("header = %v, want %s last", header, ProvenanceHeader)
+	}
+	want := strings.Join([]string{"soil carbon (CAPES)", "carbono do solo (CAPES)"}, ProvenanceSeparator)
+	if got := row[len(row)-1]; got != want {
+		t.Errorf("%s cell = %q, want %q", ProvenanceHeader, got, want)
+	}
+}
+
+func TestMergeCollectionsFollowsIDToDOI(t *testing.T) {
+	// The second search shows the same CAPES record without its DOI
+	merged := MergeCollections(
+		searchCollection("soil carbon", SearchResult{Title: "A", ID: "W1", DOI: "10.1000/abc", Source: "CAPES"}),
+		searchCollection("carbono do solo",
+			SearchResult{Title: "A", DOI: "10.1000/ABC", Source: "CAPES"},
+			SearchResult{Title: "A without DOI", ID: "W1", Source: "CAPES"},
+		),
+	)
+
+	if len(merged.Results) != 1 {
+		t.Fatalf("merged %q, want one publication", resultTitles(merged.Results))
+	}
+	want := []string{"soil carbon (CAPES)", "carbono do solo (CAPES)"}
+	if got := merged.Results[0].Provenance; !reflect.DeepEqual(got, want) {
+		t.Errorf("sources = %q, want %q", got, want)
+	}
+}
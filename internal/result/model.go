@@ -3,8 +3,11 @@ package result
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
+
+	"github.com/alexandreffaria/reviu/internal/textnorm"
 )
 
 // SearchResult represents a single research publication from search results
@@ -15,17 +18,46 @@ type SearchResult struct {
 	ID    string // Document ID (extracted from URL)
 
 	// Detailed metadata extracted from the publication page
-	Author string // Author name(s) extracted from the details page
-	Year   string // Publication year
+	Author  string   // Author name(s) extracted from the details page
+	Authors []string // Individual author names, as shown on the details page
+	// NormalizedAuthor holds the authors in "Surname, Given" form separated by
+	// "; ", filled only when author normalization is enabled
+	NormalizedAuthor string
+	Year             string   // Publication year
+	Journal          string   // Journal the publication appeared in, empty for books and conferences
+	DOI              string   // Bare DOI ("10.xxxx/..."), if the details page shows one
+	ISSN             string   // Journal ISSN in "XXXX-XXXX" form, if the details page shows one
+	Publisher        string   // Publisher shown on the details page, if any
+	Abstract         string   // Abstract text, when abstract extraction is enabled
+	Citation         string   // Ready-made citation (ABNT/APA) shown on the details page, if any
+	Keywords         []string // Author keywords shown on the details page
+	OpenAccess       bool     // Whether the publication is marked as open access
+	// OpenAccessKnown is false when the open access status couldn't be determined
+	OpenAccessKnown bool
 
 	// Additional metadata that might be available
 	Source string // Source of the publication, if available
+	// Provenance lists the searches that surfaced the result, filled when
+	// collections are merged with MergeCollections
+	Provenance []string
 
 	// Collection metadata
 	PageFound int // The page number where this result was found
 	Position  int // Position in the result list (1-based)
 }
 
+// OpenAccessLabel returns "Sim" or "Não" for the open access status,
+// or an empty string when the status is unknown
+func (r SearchResult) OpenAccessLabel() string {
+	if !r.OpenAccessKnown {
+		return ""
+	}
+	if r.OpenAccess {
+		return "Sim"
+	}
+	return "Não"
+}
+
 // NewSearchResult creates a new search result with the given title and URL
 func NewSearchResult(title, url string, pageNum, position int) SearchResult {
 	// Extract ID from URL if possible
@@ -52,9 +84,25 @@ type SearchCollection struct {
 	SearchDate   time.Time // When the search was performed
 	TotalPages   int       // Total number of pages processed
 	TotalResults int       // Total number of results collected
+	TotalFound   int       // Total number of results reported by CAPES for the search
 
 	// The actual results
 	Results []SearchResult // All search results collected
+
+	// Errors lists the pages and fields that could not be extracted
+	Errors []ExtractionError
+}
+
+// ExtractionError describes a field that could not be extracted from a page
+type ExtractionError struct {
+	URL   string `json:"url"`   // Page where the extraction failed
+	Field string `json:"field"` // Field that was being extracted (e.g. "year")
+	Cause string `json:"cause"` // Why the extraction failed
+}
+
+// Error implements the error interface
+func (e ExtractionError) Error() string {
+	return fmt.Sprintf("failed to extract %s from %s: %s", e.Field, e.URL, e.Cause)
 }
 
 // NewSearchCollection creates a new search collection
@@ -78,6 +126,15 @@ func (c *SearchCollection) AddResults(results []SearchResult) {
 	c.TotalResults = len(c.Results)
 }
 
+// AddExtractionError records that field could not be extracted from url
+func (c *SearchCollection) AddExtractionError(url, field string, cause error) {
+	extractionErr := ExtractionError{URL: url, Field: field}
+	if cause != nil {
+		extractionErr.Cause = cause.Error()
+	}
+	c.Errors = append(c.Errors, extractionErr)
+}
+
 // UpdatePageCount updates the total page count if the new count is higher
 func (c *SearchCollection) UpdatePageCount(pageCount int) {
 	if pageCount > c.TotalPages {
@@ -96,6 +153,164 @@ func (c *SearchCollection) ResultsFromPage(pageNum int) []SearchResult {
 	return pageResults
 }
 
+// IndexByID returns a map from document ID to the result holding it
+// Results without an ID are left out, and when an ID appears more than once
+// the first occurrence wins. The pointers refer to elements of c.Results, so
+// the index must be rebuilt after the collection is modified
+func (c *SearchCollection) IndexByID() map[string]*SearchResult {
+	index := make(map[string]*SearchResult, len(c.Results))
+	for i := range c.Results {
+		id := c.Results[i].ID
+		if id == "" {
+			continue
+		}
+		if _, exists := index[id]; !exists {
+			index[id] = &c.Results[i]
+		}
+	}
+	return index
+}
+
+// IndexByDOI returns a map from normalized DOI (see NormalizeDOI) to the
+// result holding it, with the same rules as IndexByID: results without a DOI
+// are left out and the first occurrence of a DOI wins
+func (c *SearchCollection) IndexByDOI() map[string]*SearchResult {
+	index := make(map[string]*SearchResult, len(c.Results))
+	for i := range c.Results {
+		doi := NormalizeDOI(c.Results[i].DOI)
+		if doi == "" {
+			continue
+		}
+		if _, exists := index[doi]; !exists {
+			index[doi] = &c.Results[i]
+		}
+	}
+	return index
+}
+
+// Deduplicate removes results sharing the same ID, or the same normalized URL
+// when the ID is empty, keeping the first occurrence and the original order
+// It returns the number of results removed
+func (c *SearchCollection) Deduplicate() int {
+	byID := c.IndexByID()
+	seenURLs := make(map[string]bool)
+	unique := make([]SearchResult, 0, len(c.Results))
+
+	for i, result := range c.Results {
+		if result.ID != "" {
+			if byID[result.ID] == &c.Results[i] {
+				unique = append(unique, result)
+			}
+			continue
+		}
+
+		// Results without an ID or URL can't be matched, so they are all kept
+		url := normalizeURL(result.URL)
+		if url == "" || !seenURLs[url] {
+			seenURLs[url] = true
+			unique = append(unique, result)
+		}
+	}
+
+	removed := len(c.Results) - len(unique)
+	c.Results = unique
+	c.TotalResults = len(unique)
+	return removed
+}
+
+// RemoveExisting removes the results already present in an export, as
+// recorded in keys, and returns the number of results removed
+func (c *SearchCollection) RemoveExisting(keys *ExistingKeys) int {
+	kept := make([]SearchResult, 0, len(c.Results))
+	for _, result := range c.Results {
+		if !keys.Contains(result) {
+			kept = append(kept, result)
+		}
+	}
+
+	removed := len(c.Results) - len(kept)
+	c.Results = kept
+	c.TotalResults = len(kept)
+	return removed
+}
+
+// authorList returns the individual authors of r, splitting the comma-joined
+// Author string when the individual names weren't kept (e.g. in results
+// restored from an older checkpoint)
+func authorList(r SearchResult) []string {
+	authors := r.Authors
+	if len(authors) == 0 && r.Author != "" {
+		authors = strings.Split(r.Author, ",")
+	}
+
+	names := make([]string, 0, len(authors))
+	for _, author := range authors {
+		if author = strings.TrimSpace(author); author != "" {
+			names = append(names, author)
+		}
+	}
+	return names
+}
+
+// FilterByAuthor keeps only the results whose Author contains one of names,
+// ignoring case and accents ("jose" matches "José Silva"), and returns the
+// number of results removed
+func (c *SearchCollection) FilterByAuthor(names []string) int {
+	folded := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = textnorm.Normalize(name); name != "" {
+			folded = append(folded, name)
+		}
+	}
+	if len(folded) == 0 {
+		return 0
+	}
+
+	kept := make([]SearchResult, 0, len(c.Results))
+	for _, result := range c.Results {
+		author := textnorm.Normalize(result.Author)
+		for _, name := range folded {
+			if strings.Contains(author, name) {
+				kept = append(kept, result)
+				break
+			}
+		}
+	}
+
+	removed := len(c.Results) - len(kept)
+	c.Results = kept
+	c.TotalResults = len(kept)
+	return removed
+}
+
+// dedupKey identifies result for Deduplicate: its ID, or its normalized URL
+// when the ID is empty. Results with neither return ""
+func dedupKey(result SearchResult) string {
+	if result.ID != "" {
+		return "id:" + result.ID
+	}
+	if url := normalizeURL(result.URL); url != "" {
+		return "url:" + url
+	}
+	return ""
+}
+
+// normalizeURL reduces a URL to a comparable form: lowercase scheme and host,
+// no fragment and no trailing slash
+func normalizeURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.Fragment = ""
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+	return parsed.String()
+}
+
 // extractIDFromURL extracts the document ID from the URL
 // Example URL: "/index.php/acervo/buscador.html?task=detalhes&source=all&id=W2004342886"
 func extractIDFromURL(urlStr string) string {
@@ -0,0 +1,88 @@
+package result
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIndexByID(t *testing.T) {
+	collection := NewSearchCollection("soil")
+	collection.AddResults([]SearchResult{
+		{ID: "W1", Title: "First"},
+		{ID: "", Title: "No ID"},
+		{ID: "W2", Title: "Second"},
+		{ID: "W1", Title: "Repeat of first"},
+		{ID: "", Title: "Another without ID"},
+	})
+
+	index := collection.IndexByID()
+	if len(index) != 2 {
+		t.Fatalf("IndexByID() has %d entries, want 2 (W1 and W2)", len(index))
+	}
+	if _, ok := index[""]; ok {
+		t.Error("IndexByID() indexed results without an ID")
+	}
+	if got := index["W1"].Title; got != "First" {
+		t.Errorf("index[W1] = %q, want the first result with the ID", got)
+	}
+	if got := index["W2"].Title; got != "Second" {
+		t.Errorf("index[W2] = %q, want %q", got, "Second")
+	}
+
+	// Entries point into the collection, so updates through them stick
+	index["W2"].Year = "2020"
+	if collection.Results[2].Year != "2020" {
+		t.Error("IndexByID() entries are copies, not the collection's results")
+	}
+}
+
+func TestIndexByIDEmptyCollection(t *testing.T) {
+	if index := NewSearchCollection("soil").IndexByID(); index == nil || len(index) != 0 {
+		t.Errorf("IndexByID() = %v, want an empty, usable map", index)
+	}
+}
+
+func TestIndexByDOI(t *testing.T) {
+	collection := NewSearchCollection("soil")
+	collection.AddResults([]SearchResult{
+		{ID: "W1", DOI: "10.1000/ABC"},
+		{ID: "W2"},
+		{ID: "W3", DOI: "https://doi.org/10.1000/abc"},
+		{ID: "W4", DOI: "doi:10.1000/xyz"},
+		{ID: "W5", DOI: "  "},
+	})
+
+	index := collection.IndexByDOI()
+	if len(index) != 2 {
+		t.Fatalf("IndexByDOI() has %d entries, want 2 (abc and xyz)", len(index))
+	}
+	if got := index["10.1000/abc"]; got == nil || got.ID != "W1" {
+		t.Errorf("index[10.1000/abc] = %v, want W1, the first result with the DOI in any form", got)
+	}
+	if got := index["10.1000/xyz"]; got == nil || got.ID != "W4" {
+		t.Errorf("index[10.1000/xyz] = %v, want W4", got)
+	}
+	if _, ok := index[""]; ok {
+		t.Error("IndexByDOI() indexed results without a DOI")
+	}
+}
+
+func TestDeduplicate(t *testing.T) {
+	collection := NewSearchCollection("soil")
+	collection.AddResults([]SearchResult{
+		{ID: "W1", Title: "First"},
+		{URL: "https://Example.org/paper/", Title: "No ID"},
+		{ID: "W1", Title: "Repeat of first"},
+		{URL: "https://example.org/paper", Title: "Same URL"},
+		{Title: "Nothing to match"},
+		{Title: "Nothing to match either"},
+	})
+
+	if removed := collection.Deduplicate(); removed != 2 {
+		t.Errorf("Deduplicate() removed %d, want 2", removed)
+	}
+	want := []string{"First", "No ID", "Nothing to match", "Nothing to match either"}
+	if got := resultTitles(collection.Results); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Deduplicate() kept %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+package result
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+)
+
+// MultiWriter writes the same results to several writers, one per export
+// format, so a single extraction feeds every output
+type MultiWriter struct {
+	writers []ResultWriter
+}
+
+// NewMultiWriter creates a writer that forwards every call to writers
+func NewMultiWriter(writers ...ResultWriter) *MultiWriter {
+	return &MultiWriter{writers: writers}
+}
+
+// Initialize prepares every writer, closing the ones already prepared if one
+// of them fails
+func (w *MultiWriter) Initialize() error {
+	for i, writer := range w.writers {
+		if err := writer.Initialize(); err != nil {
+			for _, opened := range w.writers[:i] {
+				opened.Close()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteHeader writes the header of every writer
+func (w *MultiWriter) WriteHeader() error {
+	for _, writer := range w.writers {
+		if err := writer.WriteHeader(); err != nil {
+			return fmt.Errorf("%s: %w", writer.Path(), err)
+		}
+	}
+	return nil
+}
+
+// WriteResult writes result to every writer
+func (w *MultiWriter) WriteResult(result SearchResult) error {
+	for _, writer := range w.writers {
+		if err := writer.WriteResult(result); err != nil {
+			return fmt.Errorf("%s: %w", writer.Path(), err)
+		}
+	}
+	return nil
+}
+
+// WriteResults writes results to every writer, stopping early if ctx is cancelled
+func (w *MultiWriter) WriteResults(ctx context.Context, results []SearchResult) error {
+	for _, writer := range w.writers {
+		if err := writer.WriteResults(ctx, results); err != nil {
+			return fmt.Errorf("%s: %w", writer.Path(), err)
+		}
+	}
+	return nil
+}
+
+// WriteCollection writes collection to every writer, stopping early if ctx is cancelled
+func (w *MultiWriter) WriteCollection(ctx context.Context, collection *SearchCollection) error {
+	for _, writer := range w.writers {
+		if err := writer.WriteCollection(ctx, collection); err != nil {
+			return fmt.Errorf("%s: %w", writer.Path(), err)
+		}
+	}
+	return nil
+}
+
+// Close closes every writer, even after one fails, and returns their errors
+func (w *MultiWriter) Close() error {
+	var errs []error
+	for _, writer := range w.writers {
+		if err := writer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", writer.Path(), err))
+		}
+	}
+	return stderrors.Join(errs...)
+}
+
+// Path returns the file of the first writer
+func (w *MultiWriter) Path() string {
+	if len(w.writers) == 0 {
+		return ""
+	}
+	return w.writers[0].Path()
+}
+
+// Paths returns the files of every writer, in order
+func (w *MultiWriter) Paths() []string {
+	paths := make([]string, 0, len(w.writers))
+	for _, writer := range w.writers {
+		paths = append(paths, writer.Path())
+	}
+	return paths
+}
+
+// writerPaths returns the files writer writes to
+func writerPaths(writer ResultWriter) []string {
+	if multi, ok := writer.(*MultiWriter); ok {
+		return multi.Paths()
+	}
+	return []string{writer.Path()}
+}
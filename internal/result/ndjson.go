@@ -0,0 +1,179 @@
+package result
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/alexandreffaria/reviu/internal/errors"
+	"github.com/alexandreffaria/reviu/internal/logger"
+)
+
+// ndjsonRecord is the JSON object written for each result; it keeps the
+// export format independent of SearchResult, which checkpoints also encode
+type ndjsonRecord struct {
+	ID               string   `json:"id,omitempty"`
+	Title            string   `json:"title"`
+	URL              string   `json:"url"`
+	Authors          []string `json:"authors"`
+	NormalizedAuthor string   `json:"normalizedAuthor,omitempty"`
+	Year             string   `json:"year,omitempty"`
+	Journal          string   `json:"journal,omitempty"`
+	DOI              string   `json:"doi,omitempty"`
+	ISSN             string   `json:"issn,omitempty"`
+	Publisher        string   `json:"publisher,omitempty"`
+	OpenAccess       *bool    `json:"openAccess"` // null when the status couldn't be determined
+	Abstract         string   `json:"abstract,omitempty"`
+	Citation         string   `json:"citation,omitempty"`
+	Keywords         []string `json:"keywords,omitempty"`
+	Provenance       []string `json:"provenance,omitempty"`
+	Page             int      `json:"page,omitempty"`
+	Position         int      `json:"position,omitempty"`
+}
+
+// newNDJSONRecord converts r to the exported JSON object
+func newNDJSONRecord(r SearchResult) ndjsonRecord {
+	record := ndjsonRecord{
+		ID:               r.ID,
+		Title:            r.Title,
+		URL:              r.URL,
+		Authors:          authorList(r),
+		NormalizedAuthor: r.NormalizedAuthor,
+		Year:             r.Year,
+		Journal:          r.Journal,
+		DOI:              r.DOI,
+		ISSN:             r.ISSN,
+		Publisher:        r.Publisher,
+		Abstract:         r.Abstract,
+		Citation:         r.Citation,
+		Keywords:         r.Keywords,
+		Provenance:       r.Provenance,
+		Page:             r.PageFound,
+		Position:         r.Position,
+	}
+	if r.OpenAccessKnown {
+		openAccess := r.OpenAccess
+		record.OpenAccess = &openAccess
+	}
+	return record
+}
+
+// NDJSONWriter implements ResultWriter for newline-delimited JSON, one
+// compact object per result, for piping into jq or streaming ingestion
+// Every result is flushed as it is written, so the file can be read while
+// the export is still running
+type NDJSONWriter struct {
+	config   ExportConfig
+	file     io.WriteCloser
+	writer   *bufio.Writer
+	encoder  *json.Encoder
+	log      logger.Logger
+	rowCount int
+}
+
+// NewNDJSONWriter creates a new NDJSON writer
+func NewNDJSONWriter(config ExportConfig, log logger.Logger) (*NDJSONWriter, error) {
+	if config.FilePath == "" {
+		return nil, errors.NewConfigError("file path is required for NDJSON export", nil)
+	}
+
+	if log == nil {
+		log = logger.NewLogger() // Default logger
+	}
+
+	return &NDJSONWriter{
+		config: config,
+		log:    log.WithPrefix("NDJSONExport"),
+	}, nil
+}
+
+// Initialize opens the file and prepares the encoder
+func (w *NDJSONWriter) Initialize() error {
+	file, err := openExportFile(&w.config, w.log)
+	if err != nil {
+		return err
+	}
+
+	w.file = file
+	w.writer = bufio.NewWriter(file)
+	w.encoder = json.NewEncoder(w.writer)
+	w.encoder.SetEscapeHTML(false)
+	w.log.Info("NDJSON export initialized: %s", w.config.FilePath)
+	return nil
+}
+
+// WriteHeader does nothing, NDJSON has no header
+func (w *NDJSONWriter) WriteHeader() error {
+	return nil
+}
+
+// WriteResult writes a single search result as one line and flushes it
+func (w *NDJSONWriter) WriteResult(r SearchResult) error {
+	if w.encoder == nil {
+		return errors.NewConfigError("NDJSON writer not initialized, call Initialize first", nil)
+	}
+
+	// Encode terminates every object with a newline
+	if err := w.encoder.Encode(newNDJSONRecord(r)); err != nil {
+		return errors.NewExternalError("failed to write NDJSON record", err)
+	}
+	if err := w.writer.Flush(); err != nil {
+		return errors.NewExternalError("failed to write NDJSON record", err)
+	}
+
+	w.rowCount++
+	return nil
+}
+
+// WriteResults writes multiple results, checking for cancellation between records
+func (w *NDJSONWriter) WriteResults(ctx context.Context, results []SearchResult) error {
+	for _, r := range results {
+		if err := ctx.Err(); err != nil {
+			w.log.Warn("NDJSON export cancelled after %d records", w.rowCount)
+			return err
+		}
+
+		if err := w.WriteResult(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCollection writes an entire search collection
+func (w *NDJSONWriter) WriteCollection(ctx context.Context, collection *SearchCollection) error {
+	if collection == nil {
+		return errors.NewConfigError("search collection cannot be nil", nil)
+	}
+
+	if err := w.WriteResults(ctx, collection.Results); err != nil {
+		return err
+	}
+
+	w.log.Info("Wrote %d search results to NDJSON", collection.TotalResults)
+	return nil
+}
+
+// Close flushes and closes the file
+func (w *NDJSONWriter) Close() error {
+	if w.writer == nil {
+		return nil // Nothing to close
+	}
+
+	if err := w.writer.Flush(); err != nil {
+		return errors.NewExternalError("error flushing NDJSON data", err)
+	}
+
+	if err := w.file.Close(); err != nil {
+		return errors.NewExternalError("error closing NDJSON file", err)
+	}
+
+	w.log.Info("NDJSON export completed: %s (%d records)", w.config.FilePath, w.rowCount)
+	return nil
+}
+
+// Path returns the file the records are written to
+func (w *NDJSONWriter) Path() string {
+	return w.config.FilePath
+}
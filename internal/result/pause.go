@@ -0,0 +1,99 @@
+package result
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/alexandreffaria/reviu/internal/logger"
+)
+
+// PausePollInterval is how often a paused run checks whether the pause file
+// has been removed
+const PausePollInterval = 5 * time.Second
+
+// waitWhilePaused blocks while the file at path exists, polling every interval
+// An empty path disables pausing. It returns the context error if the run is
+// cancelled while paused
+func waitWhilePaused(ctx context.Context, path string, interval time.Duration, log logger.Logger) error {
+	if path == "" || !fileExists(path) {
+		return nil
+	}
+
+	if interval <= 0 {
+		interval = PausePollInterval
+	}
+
+	log.Info("pausado: extraction paused until %s is removed", path)
+	pausedAt := time.Now()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for fileExists(path) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	log.Info("Pause file removed after %v, resuming", time.Since(pausedAt).Round(time.Second))
+	return nil
+}
+
+// fileExists reports whether something exists at path
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// paused reports whether the pause file at path exists
+func paused(path string) bool {
+	return path != "" && fileExists(path)
+}
+
+// activeTimeout limits a run to a budget of active time. Time spent paused
+// isn't counted: the context of the run is replaced after every pause by one
+// with what is left of the budget
+type activeTimeout struct {
+	parent  context.Context
+	limit   time.Duration // 0 means no limit
+	left    time.Duration
+	started time.Time
+	cancel  context.CancelFunc
+}
+
+// newActiveTimeout starts a budget of limit under parent and returns the
+// context of the run up to its first pause
+func newActiveTimeout(parent context.Context, limit time.Duration) (*activeTimeout, context.Context) {
+	t := &activeTimeout{parent: parent, limit: limit, left: limit}
+	return t, t.resume()
+}
+
+// pause stops the clock and cancels the current context
+func (t *activeTimeout) pause() {
+	if t.limit <= 0 {
+		return
+	}
+	t.left -= time.Since(t.started)
+	t.stop()
+}
+
+// resume starts the clock again and returns the context for the rest of the run
+func (t *activeTimeout) resume() context.Context {
+	if t.limit <= 0 {
+		return t.parent
+	}
+	t.started = time.Now()
+	var ctx context.Context
+	ctx, t.cancel = context.WithTimeout(t.parent, t.left)
+	return ctx
+}
+
+// stop releases the current context
+func (t *activeTimeout) stop() {
+	if t.cancel != nil {
+		t.cancel()
+	}
+}
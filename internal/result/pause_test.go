@@ -0,0 +1,87 @@
+package result
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWaitWhilePausedResumesWhenFileRemoved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pause")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- waitWhilePaused(context.Background(), path, 5*time.Millisecond, quietLogger())
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("waitWhilePaused() returned %v while the pause file exists", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	os.Remove(path)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("waitWhilePaused() = %v after the pause file was removed, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waitWhilePaused() still paused after the pause file was removed")
+	}
+}
+
+func TestWaitWhilePausedStopsOnCancel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pause")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := waitWhilePaused(ctx, path, 5*time.Millisecond, quietLogger()); err != context.DeadlineExceeded {
+		t.Errorf("waitWhilePaused() = %v, want the context error", err)
+	}
+}
+
+func TestActiveTimeoutExcludesPausedTime(t *testing.T) {
+	timeout, ctx := newActiveTimeout(context.Background(), 100*time.Millisecond)
+	defer timeout.stop()
+
+	time.Sleep(20 * time.Millisecond)
+	timeout.pause()
+	time.Sleep(150 * time.Millisecond) // Longer than the whole budget
+	ctx = timeout.resume()
+
+	if err := ctx.Err(); err != nil {
+		t.Fatalf("context expired after a pause longer than the timeout: %v", err)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("resumed context has no deadline")
+	}
+	if left := time.Until(deadline); left > 80*time.Millisecond+10*time.Millisecond || left <= 0 {
+		t.Errorf("resumed with %v left, want what remained before the pause (about 80ms)", left)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("resumed context never expired")
+	}
+}
+
+func TestActiveTimeoutWithoutLimit(t *testing.T) {
+	parent := context.Background()
+	timeout, ctx := newActiveTimeout(parent, 0)
+	timeout.pause()
+	if resumed := timeout.resume(); ctx != parent || resumed != parent {
+		t.Error("a zero timeout should leave the parent context in place")
+	}
+	timeout.stop()
+}
@@ -0,0 +1,88 @@
+package result
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PreflightCheck is the outcome of one preflight check
+type PreflightCheck struct {
+	Name   string // What was checked
+	OK     bool   // Whether the check passed
+	Detail string // Value found or reason for the failure
+}
+
+// PreflightReport lists the checks run before a long extraction
+type PreflightReport struct {
+	Checks []PreflightCheck
+}
+
+// Passed reports whether every check passed
+func (r PreflightReport) Passed() bool {
+	for _, check := range r.Checks {
+		if !check.OK {
+			return false
+		}
+	}
+	return true
+}
+
+// add records a check result
+func (r *PreflightReport) add(name string, ok bool, detail string) {
+	r.Checks = append(r.Checks, PreflightCheck{Name: name, OK: ok, Detail: detail})
+}
+
+// Preflight opens the first results page and checks that CAPES is reachable
+// and that the result count and result link selectors still match, so a
+// broken site or network is caught before a long run. Later checks are
+// skipped once one fails. The browser is left open on the results page
+func (e *CAPESResultExtractor) Preflight(ctx context.Context, searchURL string) PreflightReport {
+	var report PreflightReport
+
+	start := time.Now()
+	if err := e.browser.Open(ctx, searchURL); err != nil {
+		report.add("CAPES reachable", false, err.Error())
+		return report
+	}
+	report.add("CAPES reachable", true, fmt.Sprintf("first page loaded in %v", time.Since(start).Round(time.Millisecond)))
+
+	if err := e.browser.WaitForElement(ctx, ResultCountSelector, e.pageTimeout()); err != nil {
+		report.add("Result count selector", false, fmt.Sprintf("%s matched nothing", ResultCountSelector))
+		return report
+	}
+	totalResults, err := e.extractTotalResults(ctx)
+	if err != nil {
+		report.add("Result count selector", false, err.Error())
+		return report
+	}
+	report.add("Result count selector", true, fmt.Sprintf("%d results", totalResults))
+
+	// A search with no matches legitimately has no result links
+	if totalResults == 0 {
+		report.add("Result link selector", true, "no results to match")
+		return report
+	}
+
+	links, err := e.browser.ExtractLinks(ctx, ResultLinkSelector)
+	if err != nil {
+		report.add("Result link selector", false, err.Error())
+		return report
+	}
+	if len(links) == 0 {
+		report.add("Result link selector", false, fmt.Sprintf("%s matched nothing", ResultLinkSelector))
+		return report
+	}
+	report.add("Result link selector", true, fmt.Sprintf("%d links on the first page", len(links)))
+
+	return report
+}
+
+// pageTimeout returns the configured page timeout
+func (e *CAPESResultExtractor) pageTimeout() time.Duration {
+	timeout := time.Duration(e.options.PageTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = 15 * time.Second
+	}
+	return timeout
+}
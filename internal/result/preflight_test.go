@@ -0,0 +1,50 @@
+package result
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/alexandreffaria/reviu/internal/browser/browsertest"
+)
+
+func TestPreflightFailsWithoutResultLinks(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	fake.SetText(testSearchURL, ResultCountSelector, "45 resultados")
+
+	processor := NewResultProcessor(fake, quietLogger())
+	processor.SetOptions(testOptions())
+	report := processor.Preflight(context.Background(), testSearchURL)
+
+	if report.Passed() {
+		t.Fatalf("Preflight() passed on a page without result links: %+v", report.Checks)
+	}
+	if len(report.Checks) != 3 {
+		t.Fatalf("Preflight() ran %d checks, want reachability, count and links: %+v", len(report.Checks), report.Checks)
+	}
+	if !report.Checks[0].OK || !report.Checks[1].OK {
+		t.Errorf("checks before the links = %+v, want them passed", report.Checks[:2])
+	}
+	links := report.Checks[2]
+	if links.Name != "Result link selector" || links.OK || !strings.Contains(links.Detail, ResultLinkSelector) {
+		t.Errorf("link check = %+v, want a failure naming %s", links, ResultLinkSelector)
+	}
+
+	// The browser is closed even though preflight failed
+	if got := fake.CallCount("Close"); got != 1 {
+		t.Errorf("Close called %d times, want once after preflight", got)
+	}
+	if got := detailVisits(fake); got != 0 {
+		t.Errorf("visited %d details pages during preflight, want none", got)
+	}
+}
+
+func TestPreflightPasses(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	scriptSearch(fake, 45, []int{2})
+
+	report := newTestExtractor(fake, nil).Preflight(context.Background(), testSearchURL)
+	if !report.Passed() || len(report.Checks) != 3 {
+		t.Errorf("Preflight() = %+v, want all 3 checks passed", report.Checks)
+	}
+}
@@ -10,25 +10,90 @@ import (
 // ProcessorOptions defines options for the result processing
 type ProcessorOptions struct {
 	MaxPages          int           // Maximum number of pages to process (0 = all)
+	PageRangeStart    int           // First page to process (0 = from the first page)
+	PageRangeEnd      int           // Last page to process, overrides MaxPages (0 = no range)
+	MaxResults        int           // Stop once this many results have been detailed (0 = no limit)
 	Timeout           int           // Timeout in seconds for the entire operation
 	RetryAttempts     int           // Number of retry attempts for page navigation
+	Retry             RetryOptions  // Backoff between navigation retries (zero fields use DefaultRetryOptions)
 	PageTimeout       int           // Timeout in seconds for processing a single page
 	NavigationTimeout int           // Timeout in seconds for page navigation operations
 	PageDelay         time.Duration // Delay between pages to avoid being blocked
+	DetailDelay       time.Duration // Average spacing between detail page requests, ± DetailDelayJitter (0 = none)
+	RetryOnEmptyPage  bool          // Reload pages that return no results before the last page
+	PauseFile         string        // Pause between pages while this file exists ("" = disabled)
+	Deadline          time.Time     // Stop at the next page boundary after this time (zero = none)
+	ReuseBrowser      bool          // Navigate to the next page instead of relaunching the browser
+	CheckpointFile    string        // Save progress here after every page ("" = disabled)
+	Resume            bool          // Continue after the last page saved in CheckpointFile
+	ScreenshotOnEmpty bool          // Save a screenshot of results pages that return no links
+	ScreenshotDir     string        // Directory for those screenshots (defaults to DefaultScreenshotDir)
+	AbortOnBlock      bool          // Stop when CAPES serves a block or captcha page instead of skipping it
+
+	// Self-throttling based on page load times
+	ThrottleFactor   float64       // Slowdown over the initial load time that triggers extra delay (<= 1 = off)
+	ThrottleWindow   int           // Number of page loads in the moving average
+	ThrottleMaxDelay time.Duration // Maximum extra delay added by throttling
+
+	// ResultsReadySelector overrides the selector awaited after navigating to
+	// a results page (defaults to ResultsReadySelector)
+	ResultsReadySelector string
+
+	ExtractAbstracts        bool // Fetch the abstract of each result from its details page
+	AbstractsOpenAccessOnly bool // Only fetch abstracts for open access results
+	NormalizeAuthors        bool // Also store author names in "Surname, Given" form
+	SkipDetails             bool // Keep only what the results page shows, without visiting detail pages
+
+	Dedup              bool // Remove results with the same ID (or URL) before exporting
+
+	// StreamToDisk writes the results of every page to the export as soon as
+	// it is extracted; the collection then only keeps counts and metadata
+	StreamToDisk bool
+	TitleDedupDistance int  // Merge same-year results whose titles differ by at most this many edits (0 = off)
+	AuthorFilter       []string // Keep only results whose authors include one of these names (empty = all)
+
+	// Since keeps only results published from its year on (zero = all);
+	// SinceLastRun takes it from LastRunFile instead. An export with a
+	// LastRunFile also skips what earlier runs exported and records the run
+	Since        time.Time
+	SinceLastRun bool
+	LastRunFile  string
+
+	// DetailConcurrency is the number of detail pages opened in parallel when
+	// a detail browser factory is set (0 = one at a time on the main browser)
+	DetailConcurrency int
 }
 
+// DefaultDetailConcurrency is the default number of detail page workers
+const DefaultDetailConcurrency = 4
+
+// DefaultScreenshotDir is where screenshots of empty results pages are saved
+const DefaultScreenshotDir = "debug"
+
 // DefaultProcessorOptions returns default options for the processor
 func DefaultProcessorOptions() ProcessorOptions {
 	return ProcessorOptions{
 		MaxPages:          0,              // Process all pages
 		Timeout:           600,            // 10 minutes timeout for entire operation
 		RetryAttempts:     3,              // 3 retry attempts
+		Retry:             DefaultRetryOptions(),
 		PageTimeout:       30,             // 30 seconds per page
 		NavigationTimeout: 30,             // 30 seconds for navigation operations
 		PageDelay:         2 * time.Second, // 2 seconds delay between pages
+		RetryOnEmptyPage:  true,            // Retry empty pages
+		ReuseBrowser:      true,            // Keep the session between pages
+		AbortOnBlock:      true,            // Don't keep scraping through a block page
+		ThrottleFactor:    2.0,             // Back off when loads take twice as long
+		ThrottleWindow:    3,               // Average over the last 3 page loads
+		ThrottleMaxDelay:  60 * time.Second, // Never add more than a minute
+		DetailConcurrency: DefaultDetailConcurrency,
+		Dedup:             true,            // CAPES repeats results across pages
 	}
 }
 
+// ProgressFunc receives the progress of an extraction after every page
+type ProgressFunc func(currentPage, totalPages, totalResults int)
+
 // ResultProcessor defines the interface for processing search results
 type ResultProcessor interface {
 	// Process extracts results from all pages and returns a collection
@@ -0,0 +1,79 @@
+package result
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// DetailDelayJitter is the fraction of DetailDelay randomly added to or
+// removed from each wait, so requests don't arrive at a machine-like pace
+const DetailDelayJitter = 0.3
+
+// detailLimiter spaces detail page requests DetailDelay apart (± jitter)
+// across all detail workers, so the request rate stays bounded no matter how
+// many pages are opened in parallel
+type detailLimiter struct {
+	delay time.Duration
+
+	mu   sync.Mutex
+	next time.Time // Earliest start of the next request
+}
+
+// newDetailLimiter creates a limiter; a delay <= 0 disables it
+func newDetailLimiter(delay time.Duration) *detailLimiter {
+	return &detailLimiter{delay: delay}
+}
+
+// enabled reports whether the limiter spaces requests at all
+func (l *detailLimiter) enabled() bool {
+	return l != nil && l.delay > 0
+}
+
+// Wait blocks until the caller may open the next detail page, returning
+// early with the context error if ctx is cancelled
+func (l *detailLimiter) Wait(ctx context.Context) error {
+	if !l.enabled() {
+		return nil
+	}
+
+	// Reserve the next slot, so concurrent callers queue up one delay apart
+	l.mu.Lock()
+	start := time.Now()
+	if l.next.After(start) {
+		start = l.next
+	}
+	l.next = start.Add(l.jittered())
+	l.mu.Unlock()
+
+	wait := time.Until(start)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// jittered returns the delay varied at random by up to DetailDelayJitter
+func (l *detailLimiter) jittered() time.Duration {
+	spread := (rand.Float64()*2 - 1) * DetailDelayJitter
+	return time.Duration(float64(l.delay) * (1 + spread))
+}
+
+// RequestsPerMinute returns the average number of detail requests per minute
+// the limiter allows
+func (l *detailLimiter) RequestsPerMinute() float64 {
+	if !l.enabled() {
+		return 0
+	}
+	return float64(time.Minute) / float64(l.delay)
+}
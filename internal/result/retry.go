@@ -0,0 +1,95 @@
+package result
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexandreffaria/reviu/internal/errors"
+)
+
+// Delay returns how long to wait after the given failed attempt (1-based),
+// growing from InitialDelay by Factor on every attempt up to MaxDelay
+func (o RetryOptions) Delay(attempt int) time.Duration {
+	delay := float64(o.InitialDelay)
+	for i := 1; i < attempt; i++ {
+		delay *= o.Factor
+		if o.MaxDelay > 0 && delay >= float64(o.MaxDelay) {
+			break
+		}
+	}
+
+	if o.MaxDelay > 0 && delay > float64(o.MaxDelay) {
+		delay = float64(o.MaxDelay)
+	}
+	return time.Duration(delay) * time.Millisecond
+}
+
+// retryOptions returns the backoff settings for navigation retries, taking
+// the attempt count from RetryAttempts and defaults for anything unset
+func (e *CAPESResultExtractor) retryOptions() RetryOptions {
+	retry := e.options.Retry
+	defaults := DefaultRetryOptions()
+
+	if retry.MaxAttempts <= 0 {
+		retry.MaxAttempts = e.options.RetryAttempts
+	}
+	if retry.MaxAttempts <= 0 {
+		retry.MaxAttempts = defaults.MaxAttempts
+	}
+	if retry.InitialDelay <= 0 {
+		retry.InitialDelay = defaults.InitialDelay
+	}
+	if retry.MaxDelay <= 0 {
+		retry.MaxDelay = defaults.MaxDelay
+	}
+	if retry.Factor < 1 {
+		retry.Factor = defaults.Factor
+	}
+	return retry
+}
+
+// backoff waits before retrying after the given failed attempt, returning
+// early with the context error if ctx is cancelled
+func (e *CAPESResultExtractor) backoff(ctx context.Context, attempt int) error {
+	delay := e.retryOptions().Delay(attempt)
+	e.log.Debug("Waiting %v before retrying", delay)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// withRetry runs load until it succeeds, backing off exponentially between
+// attempts. Network and configuration errors, which retrying won't fix, are
+// returned at once
+func (e *CAPESResultExtractor) withRetry(ctx context.Context, what string, load func() error) error {
+	maxAttempts := e.retryOptions().MaxAttempts
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err = load(); err == nil {
+			return nil
+		}
+		if isPersistent(err) || attempt == maxAttempts {
+			break
+		}
+
+		e.log.Warn("Failed to %s (attempt %d of %d): %v", what, attempt, maxAttempts, err)
+		if ctxErr := e.backoff(ctx, attempt); ctxErr != nil {
+			return ctxErr
+		}
+	}
+
+	return err
+}
+
+// isPersistent reports whether err will fail the same way on every attempt
+func isPersistent(err error) bool {
+	return errors.IsErrorType(err, errors.Network) || errors.IsErrorType(err, errors.Configuration)
+}
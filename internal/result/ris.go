@@ -0,0 +1,191 @@
+package result
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alexandreffaria/reviu/internal/errors"
+	"github.com/alexandreffaria/reviu/internal/logger"
+)
+
+// risDefaultType is the RIS reference type used for articles and for any
+// publication type without a more specific mapping
+const risDefaultType = "JOUR"
+
+// risTypes maps CAPES publication types to RIS reference types
+var risTypes = map[string]string{
+	"Artigo":            "JOUR",
+	"Revisão":           "JOUR",
+	"Editorial":         "JOUR",
+	"Carta":             "JOUR",
+	"Capítulo de livro": "CHAP",
+}
+
+// RISWriter implements ResultWriter for RIS, the tagged format EndNote and
+// most other reference managers import
+type RISWriter struct {
+	config   ExportConfig
+	file     io.WriteCloser
+	writer   *bufio.Writer
+	log      logger.Logger
+	rowCount int
+}
+
+// NewRISWriter creates a new RIS writer
+func NewRISWriter(config ExportConfig, log logger.Logger) (*RISWriter, error) {
+	if config.FilePath == "" {
+		return nil, errors.NewConfigError("file path is required for RIS export", nil)
+	}
+
+	if log == nil {
+		log = logger.NewLogger() // Default logger
+	}
+
+	return &RISWriter{
+		config: config,
+		log:    log.WithPrefix("RISExport"),
+	}, nil
+}
+
+// Initialize opens the file and prepares the writer
+func (w *RISWriter) Initialize() error {
+	file, err := openExportFile(&w.config, w.log)
+	if err != nil {
+		return err
+	}
+
+	w.file = file
+	w.writer = bufio.NewWriter(file)
+	w.log.Info("RIS export initialized: %s", w.config.FilePath)
+	return nil
+}
+
+// WriteHeader does nothing, RIS has no header
+func (w *RISWriter) WriteHeader() error {
+	return nil
+}
+
+// WriteResult writes a single search result as an RIS record
+func (w *RISWriter) WriteResult(r SearchResult) error {
+	if w.writer == nil {
+		return errors.NewConfigError("RIS writer not initialized, call Initialize first", nil)
+	}
+
+	risType := risReferenceType(w.config.PublicationTypes)
+
+	var sb strings.Builder
+	writeRISTag(&sb, "TY", risType)
+	writeRISTag(&sb, "TI", r.Title)
+	for _, author := range authorList(r) {
+		writeRISTag(&sb, "AU", author)
+	}
+	writeRISTag(&sb, "PY", r.Year)
+	if risType == "JOUR" {
+		writeRISTag(&sb, "JO", r.Journal)
+	} else {
+		writeRISTag(&sb, "T2", r.Journal)
+	}
+	writeRISTag(&sb, "SN", r.ISSN)
+	writeRISTag(&sb, "PB", r.Publisher)
+	writeRISTag(&sb, "DO", r.DOI)
+	for _, keyword := range r.Keywords {
+		writeRISTag(&sb, "KW", keyword)
+	}
+	writeRISTag(&sb, "AB", r.Abstract)
+	writeRISTag(&sb, "UR", r.URL)
+	// ER is the only tag written without a value, and records are separated by
+	// a blank line
+	sb.WriteString("ER  - \n\n")
+
+	if _, err := w.writer.WriteString(sb.String()); err != nil {
+		return errors.NewExternalError("failed to write RIS record", err)
+	}
+
+	w.rowCount++
+	return nil
+}
+
+// WriteResults writes multiple results, checking for cancellation between records
+func (w *RISWriter) WriteResults(ctx context.Context, results []SearchResult) error {
+	for _, r := range results {
+		if err := ctx.Err(); err != nil {
+			w.writer.Flush()
+			w.log.Warn("RIS export cancelled after %d records", w.rowCount)
+			return err
+		}
+
+		if err := w.WriteResult(r); err != nil {
+			return err
+		}
+	}
+
+	return w.writer.Flush()
+}
+
+// WriteCollection writes an entire search collection
+func (w *RISWriter) WriteCollection(ctx context.Context, collection *SearchCollection) error {
+	if collection == nil {
+		return errors.NewConfigError("search collection cannot be nil", nil)
+	}
+
+	if err := w.WriteResults(ctx, collection.Results); err != nil {
+		return err
+	}
+
+	w.log.Info("Wrote %d search results to RIS", collection.TotalResults)
+	return nil
+}
+
+// Close flushes and closes the file
+func (w *RISWriter) Close() error {
+	if w.writer == nil {
+		return nil // Nothing to close
+	}
+
+	if err := w.writer.Flush(); err != nil {
+		return errors.NewExternalError("error flushing RIS data", err)
+	}
+
+	if err := w.file.Close(); err != nil {
+		return errors.NewExternalError("error closing RIS file", err)
+	}
+
+	w.log.Info("RIS export completed: %s (%d records)", w.config.FilePath, w.rowCount)
+	return nil
+}
+
+// Path returns the file the records are written to
+func (w *RISWriter) Path() string {
+	return w.config.FilePath
+}
+
+// risReferenceType returns the RIS type shared by all publication types the
+// search was filtered by, or JOUR when they differ or are unknown
+func risReferenceType(pubTypes []string) string {
+	risType := ""
+	for _, pubType := range pubTypes {
+		mapped, ok := risTypes[pubType]
+		if !ok || (risType != "" && mapped != risType) {
+			return risDefaultType
+		}
+		risType = mapped
+	}
+
+	if risType == "" {
+		return risDefaultType
+	}
+	return risType
+}
+
+// writeRISTag writes "TAG  - value" on its own line, skipping empty values
+// RIS values can't span lines, so whitespace is collapsed
+func writeRISTag(sb *strings.Builder, tag, value string) {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(sb, "%s  - %s\n", tag, value)
+}
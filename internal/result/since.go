@@ -0,0 +1,135 @@
+package result
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/alexandreffaria/reviu/internal/errors"
+)
+
+// lastRunExtension is appended to the output file to name its run record
+const lastRunExtension = ".lastrun"
+
+// LastRun records an incremental (-since) export: when it ran and every
+// publication exported so far, so the next run only adds new ones
+type LastRun struct {
+	SearchURL string    `json:"searchURL"`
+	RunAt     time.Time `json:"runAt"`
+	IDs       []string  `json:"ids"`
+	DOIs      []string  `json:"dois"`
+}
+
+// LastRunPath returns the run record file for an export to outputFile
+func LastRunPath(outputFile string) string {
+	return outputFile + lastRunExtension
+}
+
+// LoadLastRun reads the run record at path
+// A missing file returns nil without an error
+func LoadLastRun(path string) (*LastRun, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.NewExternalError("failed to read run record", err)
+	}
+
+	var run LastRun
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, errors.NewExternalError("run record file is corrupt", err)
+	}
+	return &run, nil
+}
+
+// Keys returns the identifiers of the publications exported by earlier runs
+func (r *LastRun) Keys() *ExistingKeys {
+	keys := NewExistingKeys()
+	for _, id := range r.IDs {
+		keys.IDs[id] = true
+	}
+	for _, doi := range r.DOIs {
+		keys.DOIs[doi] = true
+	}
+	return keys
+}
+
+// saveLastRun records a run at runAt to path, keeping the publications of
+// known along with those in collection
+// The file is replaced atomically so a crash never leaves half a record
+func saveLastRun(path, searchURL string, runAt time.Time, known *ExistingKeys, collection *SearchCollection) error {
+	keys := NewExistingKeys()
+	if known != nil {
+		keys.Merge(known)
+	}
+	keys.AddCollection(collection)
+
+	run := LastRun{
+		SearchURL: searchURL,
+		RunAt:     runAt,
+		IDs:       sortedKeys(keys.IDs),
+		DOIs:      sortedKeys(keys.DOIs),
+	}
+
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return errors.NewError(errors.Unknown, "failed to encode run record", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return errors.NewExternalError("failed to write run record", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.NewExternalError("failed to replace run record", err)
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of set in order, so run records diff cleanly
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// publicationYear returns the first four-digit year in r.Year
+// CAPES sometimes shows a full date or a range, so the year isn't always alone
+func publicationYear(r SearchResult) (int, bool) {
+	digits := 0
+	for i, c := range r.Year {
+		if c < '0' || c > '9' {
+			digits = 0
+			continue
+		}
+		digits++
+		if digits == 4 && (i+1 == len(r.Year) || r.Year[i+1] < '0' || r.Year[i+1] > '9') {
+			year, err := strconv.Atoi(r.Year[i-3 : i+1])
+			return year, err == nil
+		}
+	}
+	return 0, false
+}
+
+// FilterSince keeps only the results published in the year of since or later
+// and returns the number of results removed. Detail pages only show the year,
+// so a date in the middle of a year keeps the whole year; results without a
+// year are kept, since there's no telling when they were published
+func (c *SearchCollection) FilterSince(since time.Time) int {
+	kept := make([]SearchResult, 0, len(c.Results))
+	for _, result := range c.Results {
+		if year, ok := publicationYear(result); !ok || year >= since.Year() {
+			kept = append(kept, result)
+		}
+	}
+
+	removed := len(c.Results) - len(kept)
+	c.Results = kept
+	c.TotalResults = len(kept)
+	return removed
+}
@@ -0,0 +1,43 @@
+package result
+
+import (
+	"time"
+)
+
+// RunSummary is a machine-readable description of an export run
+// It is built from the statistics the processor tracks while extracting
+// and exporting, so orchestrators don't need to parse logs or the CSV
+type RunSummary struct {
+	SearchTerm        string            `json:"searchTerm"`
+	SearchURL         string            `json:"searchURL"`
+	TotalFound        int               `json:"totalFound"`
+	PagesProcessed    int               `json:"pagesProcessed"`
+	ResultsCollected  int               `json:"resultsCollected"`
+	ResultsWritten    int               `json:"resultsWritten"`
+	DuplicatesRemoved int               `json:"duplicatesRemoved"`
+	FilteredOut       int               `json:"filteredOut"`
+	DurationSeconds   float64           `json:"durationSeconds"`
+	OutputFiles       []string          `json:"outputFiles"`
+	ExtractionErrors  []ExtractionError `json:"extractionErrors"`
+	Interrupted       bool              `json:"interrupted"` // The run was stopped early and the export is partial
+}
+
+// newRunSummary creates a summary seeded with the collection statistics
+func newRunSummary(collection *SearchCollection, searchURL string, duration time.Duration) RunSummary {
+	summary := RunSummary{
+		SearchURL:        searchURL,
+		DurationSeconds:  duration.Seconds(),
+		OutputFiles:      []string{},
+		ExtractionErrors: []ExtractionError{},
+	}
+
+	if collection != nil {
+		summary.SearchTerm = collection.SearchTerm
+		summary.TotalFound = collection.TotalFound
+		summary.PagesProcessed = collection.TotalPages
+		summary.ResultsCollected = collection.TotalResults
+		summary.ExtractionErrors = append(summary.ExtractionErrors, collection.Errors...)
+	}
+
+	return summary
+}
@@ -0,0 +1,79 @@
+package result
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexandreffaria/reviu/internal/browser/browsertest"
+	"github.com/alexandreffaria/reviu/internal/config"
+)
+
+func TestRunSummaryJSON(t *testing.T) {
+	fake := browsertest.NewFakeBrowser()
+	scriptSearch(fake, 2, []int{2})
+
+	params := config.NewSearchParams()
+	params.SearchTerm = "soil"
+	params.OutputFile = filepath.Join(t.TempDir(), "results.csv")
+
+	processor := NewResultProcessor(fake, quietLogger())
+	processor.SetOptions(testOptions())
+	if err := processor.ProcessAndExport(context.Background(), params, testSearchURL); err != nil {
+		t.Fatalf("ProcessAndExport() error = %v", err)
+	}
+
+	data, err := json.Marshal(processor.Summary())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary map[string]interface{}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("summary isn't a JSON object: %v", err)
+	}
+
+	// Numbers decode as float64, lists as []interface{}
+	kinds := map[string]string{
+		"searchTerm":        "string",
+		"searchURL":         "string",
+		"totalFound":        "number",
+		"pagesProcessed":    "number",
+		"resultsCollected":  "number",
+		"resultsWritten":    "number",
+		"duplicatesRemoved": "number",
+		"filteredOut":       "number",
+		"durationSeconds":   "number",
+		"outputFiles":       "array",
+		"extractionErrors":  "array",
+		"interrupted":       "bool",
+	}
+	for key, kind := range kinds {
+		value, ok := summary[key]
+		if !ok {
+			t.Errorf("summary has no %q key", key)
+			continue
+		}
+		var got string
+		switch value.(type) {
+		case string:
+			got = "string"
+		case float64:
+			got = "number"
+		case []interface{}:
+			got = "array"
+		case bool:
+			got = "bool"
+		}
+		if got != kind {
+			t.Errorf("summary[%q] = %#v, want a %s", key, value, kind)
+		}
+	}
+
+	if summary["searchURL"] != testSearchURL || summary["resultsWritten"] != 2.0 {
+		t.Errorf("summary = %v, want the search URL and 2 results written", summary)
+	}
+	if files := summary["outputFiles"].([]interface{}); len(files) != 2 || files[0] != params.OutputFile {
+		t.Errorf("outputFiles = %v, want the export and its summary", files)
+	}
+}
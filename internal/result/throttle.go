@@ -0,0 +1,108 @@
+package result
+
+import (
+	"time"
+)
+
+// loadThrottle watches page load durations and grows the delay between pages
+// when CAPES starts answering noticeably slower than at the start of the run,
+// which often precedes a block
+type loadThrottle struct {
+	window   int           // Number of samples in the moving average
+	factor   float64       // Slowdown ratio over the baseline that triggers backoff
+	maxDelay time.Duration // Upper bound for the extra delay
+
+	samples    []time.Duration
+	baseline   time.Duration
+	extraDelay time.Duration
+}
+
+// newLoadThrottle creates a throttle; a factor <= 1 disables it
+func newLoadThrottle(factor float64, window int, maxDelay time.Duration) *loadThrottle {
+	if window <= 0 {
+		window = 3
+	}
+	return &loadThrottle{
+		window:   window,
+		factor:   factor,
+		maxDelay: maxDelay,
+	}
+}
+
+// enabled reports whether the throttle adjusts delays at all
+func (t *loadThrottle) enabled() bool {
+	return t != nil && t.factor > 1
+}
+
+// Record adds a page load duration and updates the extra delay
+func (t *loadThrottle) Record(d time.Duration) {
+	if !t.enabled() {
+		return
+	}
+
+	t.samples = append(t.samples, d)
+	if len(t.samples) > t.window*4 {
+		t.samples = t.samples[len(t.samples)-t.window*4:]
+	}
+
+	// The first full window establishes what "normal" looks like
+	if t.baseline == 0 {
+		if len(t.samples) >= t.window {
+			t.baseline = average(t.samples[:t.window])
+		}
+		return
+	}
+
+	current := t.MovingAverage()
+	threshold := time.Duration(float64(t.baseline) * t.factor)
+
+	if current > threshold {
+		// Slowing down: double the extra delay, starting from the baseline itself
+		if t.extraDelay == 0 {
+			t.extraDelay = t.baseline
+		} else {
+			t.extraDelay *= 2
+		}
+		if t.maxDelay > 0 && t.extraDelay > t.maxDelay {
+			t.extraDelay = t.maxDelay
+		}
+	} else if t.extraDelay > 0 {
+		// Recovered: back off gradually
+		t.extraDelay /= 2
+		if t.extraDelay < time.Second {
+			t.extraDelay = 0
+		}
+	}
+}
+
+// MovingAverage returns the average of the most recent samples
+func (t *loadThrottle) MovingAverage() time.Duration {
+	if len(t.samples) == 0 {
+		return 0
+	}
+	start := len(t.samples) - t.window
+	if start < 0 {
+		start = 0
+	}
+	return average(t.samples[start:])
+}
+
+// Delay returns the inter-page delay to use given the configured base delay
+func (t *loadThrottle) Delay(base time.Duration) time.Duration {
+	if !t.enabled() {
+		return base
+	}
+	return base + t.extraDelay
+}
+
+// average returns the mean of the given durations
+func average(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return total / time.Duration(len(durations))
+}
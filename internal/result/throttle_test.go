@@ -0,0 +1,52 @@
+package result
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadThrottleGrowsDelayWithLoadTimes(t *testing.T) {
+	const base = 2 * time.Second
+	throttle := newLoadThrottle(1.5, 3, 5*time.Second)
+
+	// A steady start sets the baseline without any extra delay
+	for i := 0; i < 3; i++ {
+		throttle.Record(time.Second)
+	}
+	if got := throttle.Delay(base); got != base {
+		t.Fatalf("Delay() = %v after steady loads, want the base %v", got, base)
+	}
+
+	previous := base
+	for _, load := range []time.Duration{2, 3, 4, 6, 8, 12} {
+		throttle.Record(load * time.Second)
+		delay := throttle.Delay(base)
+		if delay < previous {
+			t.Errorf("Delay() = %v after a %ds load, want no less than %v", delay, load, previous)
+		}
+		previous = delay
+	}
+	if want := base + 5*time.Second; previous != want {
+		t.Errorf("Delay() = %v after rising loads, want it grown to the %v cap", previous, want)
+	}
+
+	// Faster loads shrink the extra delay again
+	for i := 0; i < 6; i++ {
+		throttle.Record(time.Second)
+	}
+	if got := throttle.Delay(base); got != base {
+		t.Errorf("Delay() = %v after recovering, want the base %v", got, base)
+	}
+}
+
+func TestLoadThrottleDisabled(t *testing.T) {
+	const base = 2 * time.Second
+	for _, throttle := range []*loadThrottle{nil, newLoadThrottle(1, 3, 0)} {
+		for _, load := range []time.Duration{1, 1, 1, 10, 20, 40} {
+			throttle.Record(load * time.Second)
+		}
+		if got := throttle.Delay(base); got != base {
+			t.Errorf("Delay() = %v with the throttle off, want the base %v", got, base)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package result
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alexandreffaria/reviu/internal/errors"
+	"github.com/alexandreffaria/reviu/internal/logger"
+)
+
+// Column widths of the XLSX sheet, in characters
+const (
+	xlsxMinColumnWidth = 8
+	xlsxMaxColumnWidth = 80
+)
+
+// XLSXWriter implements ResultWriter for Excel workbooks
+// A workbook can't be written row by row, so results are buffered and the
+// sheet is written on Close
+type XLSXWriter struct {
+	config  ExportConfig
+	columns columnSet
+	file    io.WriteCloser
+	log     logger.Logger
+	rows    [][]string
+}
+
+// NewXLSXWriter creates a new XLSX writer
+func NewXLSXWriter(config ExportConfig, log logger.Logger) (*XLSXWriter, error) {
+	if config.FilePath == "" {
+		return nil, errors.NewConfigError("file path is required for XLSX export", nil)
+	}
+
+	if log == nil {
+		log = logger.NewLogger() // Default logger
+	}
+
+	columns, err := config.columns()
+	if err != nil {
+		return nil, err
+	}
+
+	return &XLSXWriter{
+		config:  config,
+		columns: columns,
+		log:     log.WithPrefix("XLSXExport"),
+	}, nil
+}
+
+// Initialize opens the file the workbook will be written to
+func (w *XLSXWriter) Initialize() error {
+	file, err := openExportFile(&w.config, w.log)
+	if err != nil {
+		return err
+	}
+
+	w.file = file
+	w.log.Info("XLSX export initialized: %s", w.config.FilePath)
+
+	if w.config.IncludeHeader {
+		return w.WriteHeader()
+	}
+	return nil
+}
+
+// WriteHeader adds the header row, which is frozen in the sheet
+func (w *XLSXWriter) WriteHeader() error {
+	if w.file == nil {
+		return errors.NewConfigError("XLSX writer not initialized, call Initialize first", nil)
+	}
+
+	if len(w.rows) == 0 {
+		w.rows = append(w.rows, w.columns.header())
+	}
+	return nil
+}
+
+// WriteResult buffers a single search result
+func (w *XLSXWriter) WriteResult(r SearchResult) error {
+	if w.file == nil {
+		return errors.NewConfigError("XLSX writer not initialized, call Initialize first", nil)
+	}
+
+	w.rows = append(w.rows, w.columns.row(w.config, r))
+	return nil
+}
+
+// WriteResults buffers multiple results, checking for cancellation first
+func (w *XLSXWriter) WriteResults(ctx context.Context, results []SearchResult) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		if err := w.WriteResult(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCollection buffers an entire search collection
+func (w *XLSXWriter) WriteCollection(ctx context.Context, collection *SearchCollection) error {
+	if collection == nil {
+		return errors.NewConfigError("search collection cannot be nil", nil)
+	}
+
+	if err := w.WriteResults(ctx, collection.Results); err != nil {
+		return err
+	}
+
+	w.log.Info("Buffered %d search results for XLSX", collection.TotalResults)
+	return nil
+}
+
+// Close writes the workbook and closes the file
+func (w *XLSXWriter) Close() error {
+	if w.file == nil {
+		return nil // Nothing to close
+	}
+
+	writeErr := w.writeWorkbook()
+	closeErr := w.file.Close()
+	w.file = nil
+
+	if writeErr != nil {
+		return errors.NewExternalError("error writing XLSX workbook", writeErr)
+	}
+	if closeErr != nil {
+		return errors.NewExternalError("error closing XLSX file", closeErr)
+	}
+
+	w.log.Info("XLSX export completed: %s (%d rows)", w.config.FilePath, len(w.rows))
+	return nil
+}
+
+// Path returns the file the workbook is written to
+func (w *XLSXWriter) Path() string {
+	return w.config.FilePath
+}
+
+// xlsxStaticParts are the workbook files that don't depend on the results
+var xlsxStaticParts = []struct{ name, content string }{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Resultados" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`},
+	// Style 1 is the bold font used by the header row
+	{"xl/styles.xml", xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border/></borders>` +
+		`<cellStyleXfs count="1"><xf/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf fontId="0"/><xf fontId="1" applyFont="1"/></cellXfs>` +
+		`</styleSheet>`},
+}
+
+// writeWorkbook writes the buffered rows as a single-sheet workbook
+func (w *XLSXWriter) writeWorkbook() error {
+	archive := zip.NewWriter(w.file)
+
+	for _, part := range xlsxStaticParts {
+		entry, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(entry, part.content); err != nil {
+			return err
+		}
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	if err := w.writeSheet(sheet); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// writeSheet writes the worksheet XML with sized columns and, when there is
+// a header, a frozen first row
+func (w *XLSXWriter) writeSheet(out io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	hasHeader := w.config.IncludeHeader && len(w.rows) > 0
+	if hasHeader {
+		sb.WriteString(`<sheetViews><sheetView workbookViewId="0">` +
+			`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>` +
+			`</sheetView></sheetViews>`)
+	}
+
+	if widths := columnWidths(w.rows); len(widths) > 0 {
+		sb.WriteString("<cols>")
+		for i, width := range widths {
+			fmt.Fprintf(&sb, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, width)
+		}
+		sb.WriteString("</cols>")
+	}
+
+	sb.WriteString("<sheetData>")
+	for i, row := range w.rows {
+		fmt.Fprintf(&sb, `<row r="%d">`, i+1)
+		style := ""
+		if i == 0 && hasHeader {
+			style = ` s="1"`
+		}
+		for j, cell := range row {
+			fmt.Fprintf(&sb, `<c r="%s%d" t="inlineStr"%s><is><t xml:space="preserve">`, columnName(j), i+1, style)
+			if err := xml.EscapeText(&sb, []byte(xlsxText(cell))); err != nil {
+				return err
+			}
+			sb.WriteString("</t></is></c>")
+		}
+		sb.WriteString("</row>")
+	}
+	sb.WriteString("</sheetData></worksheet>")
+
+	_, err := io.WriteString(out, sb.String())
+	return err
+}
+
+// columnWidths sizes each column to its longest cell, within limits
+func columnWidths(rows [][]string) []int {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, xlsxMinColumnWidth)
+			}
+			if width := utf8.RuneCountInString(cell) + 2; width > widths[i] {
+				widths[i] = min(width, xlsxMaxColumnWidth)
+			}
+		}
+	}
+	return widths
+}
+
+// columnName converts a zero-based column index to its letters (0 = "A", 26 = "AA")
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// xlsxText removes the control characters XML 1.0 can't represent
+func xlsxText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -4,6 +4,7 @@ package search
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 	
 	"github.com/alexandreffaria/reviu/internal/config"
@@ -41,55 +42,8 @@ func (b *CAPESURLBuilder) BuildSearchURL(params *config.SearchParams) (string, e
 		return "", errors.NewConfigError("parameters must be validated before building URL", nil)
 	}
 	
-	// Construct query parameters in the required order
-	var urlParams []string
-	
-	// Add search term (required parameter)
-	termEncoded := encodeSearchTerm(params.SearchTerm)
-	urlParams = append(urlParams, "q="+termEncoded)
-	
-	// Add empty source parameter (required by CAPES)
-	urlParams = append(urlParams, "source=")
-	
-	// Add optional parameters
-	
-	// Open Access parameter
-	if params.AccessType != "" {
-		openAccessParam := buildOpenAccessParam(params.AccessType)
-		urlParams = append(urlParams, openAccessParam)
-	}
-	
-	// Publication Type parameter
-	if params.PublicationType != "" {
-		typeParam := buildPublicationTypeParam(params.PublicationType)
-		urlParams = append(urlParams, typeParam)
-	}
-	
-	// Year parameters
-	if params.YearMin > 0 {
-		yearMinParam := fmt.Sprintf("publishyear_min%%5B%%5D=%d", params.YearMin)
-		urlParams = append(urlParams, yearMinParam)
-	}
-	
-	if params.EffectiveYearMax > 0 {
-		yearMaxParam := fmt.Sprintf("publishyear_max%%5B%%5D=%d", params.EffectiveYearMax)
-		urlParams = append(urlParams, yearMaxParam)
-	}
-	
-	// Peer Review parameter
-	if params.PeerReviewed != "" {
-		peerReviewParam := buildPeerReviewParam(params.PeerReviewed)
-		urlParams = append(urlParams, peerReviewParam)
-	}
-	
-	// Language parameters
-	for _, lang := range params.Languages {
-		langParam := buildLanguageParam(lang)
-		urlParams = append(urlParams, langParam)
-	}
-	
 	// Construct final URL
-	finalURL := b.baseURL + "?" + strings.Join(urlParams, "&")
+	finalURL := b.baseURL + "?" + BuildQuery(params)
 	
 	if b.log != nil {
 		b.log.Debug("Built search URL: %s", finalURL)
@@ -98,13 +52,142 @@ func (b *CAPESURLBuilder) BuildSearchURL(params *config.SearchParams) (string, e
 	return finalURL, nil
 }
 
+// queryParam builds the query string fragments for one CAPES parameter
+// It returns nil when the parameter doesn't apply to params
+type queryParam struct {
+	name  string
+	build func(params *config.SearchParams) []string
+}
+
+// searchQueryOrder is the order in which parameters appear in the search URL
+//
+// CAPES matches searches against the exact query string its own interface
+// produces, so this order is a contract and must not change:
+//
+//	q, source, open_access[], type[] (one per type, in the order given),
+//	publishyear_min[], publishyear_max[],
+//	peer_reviewed[], language[] (one per language, in the order given),
+//	knowledge_area[] (one per area, in the order given), sort
+//
+// q and source are always present; the other parameters only when set.
+var searchQueryOrder = []queryParam{
+	{"q", func(p *config.SearchParams) []string {
+		return []string{"q=" + encodeSearchTerm(p.SearchTerm, p.QueryMode)}
+	}},
+	{"source", func(p *config.SearchParams) []string {
+		// Required by CAPES, empty means all sources
+		return []string{"source=" + url.QueryEscape(p.Source)}
+	}},
+	{"open_access", func(p *config.SearchParams) []string {
+		if p.AccessType == "" {
+			return nil
+		}
+		return []string{buildOpenAccessParam(p.AccessType)}
+	}},
+	{"type", func(p *config.SearchParams) []string {
+		var typeParams []string
+		for _, pubType := range p.PublicationTypes {
+			typeParams = append(typeParams, buildPublicationTypeParam(pubType))
+		}
+		return typeParams
+	}},
+	{"publishyear_min", func(p *config.SearchParams) []string {
+		if p.YearMin <= 0 {
+			return nil
+		}
+		return []string{fmt.Sprintf("publishyear_min%%5B%%5D=%d", p.YearMin)}
+	}},
+	{"publishyear_max", func(p *config.SearchParams) []string {
+		if p.EffectiveYearMax <= 0 {
+			return nil
+		}
+		return []string{fmt.Sprintf("publishyear_max%%5B%%5D=%d", p.EffectiveYearMax)}
+	}},
+	{"peer_reviewed", func(p *config.SearchParams) []string {
+		if p.PeerReviewed == "" {
+			return nil
+		}
+		return []string{buildPeerReviewParam(p.PeerReviewed)}
+	}},
+	{"language", func(p *config.SearchParams) []string {
+		var langParams []string
+		for _, lang := range p.Languages {
+			langParams = append(langParams, buildLanguageParam(lang))
+		}
+		return langParams
+	}},
+	{"knowledge_area", func(p *config.SearchParams) []string {
+		var areaParams []string
+		for _, area := range p.SubjectAreas {
+			areaParams = append(areaParams, buildKnowledgeAreaParam(area))
+		}
+		return areaParams
+	}},
+	{"sort", func(p *config.SearchParams) []string {
+		if p.SortOrder == "" {
+			return nil
+		}
+		return []string{buildSortParam(p.SortOrder)}
+	}},
+}
+
+// BuildQuery returns the query string of the search URL for params, with the
+// parameters in the order documented on searchQueryOrder
+func BuildQuery(params *config.SearchParams) string {
+	var urlParams []string
+	for _, param := range searchQueryOrder {
+		urlParams = append(urlParams, param.build(params)...)
+	}
+	return strings.Join(urlParams, "&")
+}
+
 // Helper functions for parameter encoding and construction
 
 // encodeSearchTerm properly encodes the search term for the CAPES portal
-func encodeSearchTerm(term string) string {
+// according to the query mode (see config.QueryModes)
+func encodeSearchTerm(term, mode string) string {
+	switch mode {
+	case config.QueryModeExact:
+		term = `"` + strings.Trim(term, `" `) + `"`
+	case config.QueryModeBoolean:
+		term = normalizeBooleanOperators(term)
+	}
+	
 	encoded := url.QueryEscape(term)
 	// CAPES uses + instead of %20 for spaces
-	return strings.ReplaceAll(encoded, "%20", "+")
+	encoded = strings.ReplaceAll(encoded, "%20", "+")
+	
+	if mode == config.QueryModeBoolean {
+		// Grouping is part of the expression, so it goes through as typed
+		encoded = booleanUnescaper.Replace(encoded)
+	}
+	return encoded
+}
+
+// booleanUnescaper restores the grouping characters of boolean queries
+var booleanUnescaper = strings.NewReplacer("%28", "(", "%29", ")")
+
+// booleanOperators are the operators CAPES recognizes, only in upper case
+var booleanOperators = []string{"AND", "OR", "NOT"}
+
+// normalizeBooleanOperators upper-cases operators typed in lower case, leaving
+// words inside quoted phrases alone
+func normalizeBooleanOperators(term string) string {
+	words := strings.Fields(term)
+	quoted := false
+	for i, word := range words {
+		if !quoted {
+			for _, op := range booleanOperators {
+				if strings.EqualFold(word, op) {
+					words[i] = op
+				}
+			}
+		}
+		if strings.Count(word, `"`)%2 == 1 {
+			quoted = !quoted
+		}
+	}
+	return strings.Join(words, " ")
 }
 
 // buildOpenAccessParam constructs the open access parameter
@@ -129,9 +212,103 @@ func buildPeerReviewParam(peerReview string) string {
 	return "peer_reviewed%5B%5D=peer_reviewed%3D%3D0"
 }
 
+// sortParamValues maps the -sort values to the ordering CAPES expects
+var sortParamValues = map[string]string{
+	"relevance": "relevance",
+	"date_desc": "publishyear_desc",
+	"date_asc":  "publishyear_asc",
+	"title":     "title_asc",
+}
+
+// buildSortParam constructs the result ordering parameter
+func buildSortParam(sortOrder string) string {
+	return "sort=" + url.QueryEscape(sortParamValues[sortOrder])
+}
+
 // buildLanguageParam constructs a language parameter
+// The whole filter is escaped, so every accented name ("Português",
+// "Español", "Francês") is sent UTF-8 percent-encoded as CAPES expects
 func buildLanguageParam(lang string) string {
-	// Special handling for Portuguese and other diacritics
-	langEncoded := strings.ReplaceAll(lang, "ê", "%C3%AA")
-	return fmt.Sprintf("language%%5B%%5D=language%%3D%%3D%s", langEncoded)
-}
\ No newline at end of file
+	langEncoded := url.QueryEscape("language==" + lang)
+	return "language%5B%5D=" + langEncoded
+}
+// buildKnowledgeAreaParam constructs a knowledge area parameter
+func buildKnowledgeAreaParam(area string) string {
+	areaEncoded := url.QueryEscape("knowledge_area==" + area)
+	return "knowledge_area%5B%5D=" + areaEncoded
+}
+
+// ParseSearchURL reads the search filters back from a CAPES search URL, as
+// built by BuildSearchURL. A quoted term comes back as an exact search and
+// any other term as a simple one, since the query string doesn't say whether
+// a term was sent as a boolean expression
+func ParseSearchURL(searchURL string) (*config.SearchParams, error) {
+	u, err := url.Parse(searchURL)
+	if err != nil {
+		return nil, errors.NewUserInputError("invalid search URL", err)
+	}
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, errors.NewUserInputError("invalid search URL query", err)
+	}
+	if !query.Has("q") {
+		return nil, errors.NewUserInputError(fmt.Sprintf("%s is not a CAPES search URL, it has no q parameter", searchURL), nil)
+	}
+
+	params := config.NewSearchParams()
+	params.SearchTerm = query.Get("q")
+	if len(params.SearchTerm) > 1 && strings.HasPrefix(params.SearchTerm, `"`) && strings.HasSuffix(params.SearchTerm, `"`) {
+		params.SearchTerm = strings.Trim(params.SearchTerm, `"`)
+		params.QueryMode = config.QueryModeExact
+	}
+	params.Source = query.Get("source")
+
+	if value := query.Get("open_access[]"); value != "" {
+		params.AccessType = parseFlagFilter(value, "open_access")
+	}
+	if value := query.Get("peer_reviewed[]"); value != "" {
+		params.PeerReviewed = parseFlagFilter(value, "peer_reviewed")
+	}
+	params.PublicationTypes = filterValues(query["type[]"], "type")
+	params.Languages = filterValues(query["language[]"], "language")
+	params.SubjectAreas = filterValues(query["knowledge_area[]"], "knowledge_area")
+
+	if value := query.Get("publishyear_min[]"); value != "" {
+		if params.YearMin, err = strconv.Atoi(value); err != nil {
+			return nil, errors.NewUserInputError(fmt.Sprintf("invalid minimum year %q in search URL", value), err)
+		}
+	}
+	if value := query.Get("publishyear_max[]"); value != "" {
+		if params.YearMax, err = strconv.Atoi(value); err != nil {
+			return nil, errors.NewUserInputError(fmt.Sprintf("invalid maximum year %q in search URL", value), err)
+		}
+		params.EffectiveYearMax = params.YearMax
+	}
+
+	if value := query.Get("sort"); value != "" {
+		for sortOrder, capesValue := range sortParamValues {
+			if capesValue == value {
+				params.SortOrder = sortOrder
+			}
+		}
+	}
+
+	return params, nil
+}
+
+// parseFlagFilter turns a "name==1" or "name==0" filter into "sim" or "nao"
+func parseFlagFilter(value, name string) string {
+	if value == name+"==1" {
+		return "sim"
+	}
+	return "nao"
+}
+
+// filterValues returns the values of "name==value" filters, in order
+func filterValues(values []string, name string) []string {
+	var filters []string
+	for _, value := range values {
+		filters = append(filters, strings.TrimPrefix(value, name+"=="))
+	}
+	return filters
+}
@@ -0,0 +1,169 @@
+package search
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alexandreffaria/reviu/internal/config"
+)
+
+// update rewrites the golden files from the current output
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// validParams returns validated parameters searching for term
+func validParams(term string) *config.SearchParams {
+	params := config.NewSearchParams()
+	params.SearchTerm = term
+	params.Valid = true
+	return params
+}
+
+func TestBuildSearchURLSource(t *testing.T) {
+	builder := NewCAPESURLBuilder(nil)
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"", "q=soil&source="},
+		{"all", "q=soil&source=all"},
+		{"Web of Science", "q=soil&source=Web+of+Science"},
+		{"scielo&doaj", "q=soil&source=scielo%26doaj"},
+	}
+
+	for _, tt := range tests {
+		params := validParams("soil")
+		params.Source = tt.source
+
+		searchURL, err := builder.BuildSearchURL(params)
+		if err != nil {
+			t.Fatalf("BuildSearchURL(source %q) error = %v", tt.source, err)
+		}
+		if query := searchURL[strings.Index(searchURL, "?")+1:]; query != tt.want {
+			t.Errorf("BuildSearchURL(source %q) query = %q, want %q", tt.source, query, tt.want)
+		}
+
+		parsed, err := ParseSearchURL(searchURL)
+		if err != nil {
+			t.Fatalf("ParseSearchURL(%q) error = %v", searchURL, err)
+		}
+		if parsed.Source != tt.source {
+			t.Errorf("ParseSearchURL(%q).Source = %q, want %q", searchURL, parsed.Source, tt.source)
+		}
+	}
+}
+
+func TestParseSearchURLRoundTrip(t *testing.T) {
+	params := validParams("soil carbon")
+	params.Source = "scielo"
+	params.AccessType = "sim"
+	params.PublicationTypes = []string{"Artigo", "Livro"}
+	params.YearMin = 2015
+	params.YearMax = 2020
+	params.EffectiveYearMax = 2020
+	params.PeerReviewed = "nao"
+	params.Languages = []string{"Português", "Español"}
+	params.SubjectAreas = []string{"Ciências Agrárias"}
+	params.SortOrder = "date_desc"
+
+	searchURL, err := NewCAPESURLBuilder(nil).BuildSearchURL(params)
+	if err != nil {
+		t.Fatalf("BuildSearchURL() error = %v", err)
+	}
+	parsed, err := ParseSearchURL(searchURL)
+	if err != nil {
+		t.Fatalf("ParseSearchURL() error = %v", err)
+	}
+
+	got := []interface{}{parsed.SearchTerm, parsed.Source, parsed.AccessType, parsed.PublicationTypes,
+		parsed.YearMin, parsed.EffectiveYearMax, parsed.PeerReviewed, parsed.Languages, parsed.SubjectAreas, parsed.SortOrder}
+	want := []interface{}{params.SearchTerm, params.Source, params.AccessType, params.PublicationTypes,
+		params.YearMin, params.EffectiveYearMax, params.PeerReviewed, params.Languages, params.SubjectAreas, params.SortOrder}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseSearchURL() = %v, want %v", got, want)
+	}
+	if query := BuildQuery(parsed); query != BuildQuery(params) {
+		t.Errorf("rebuilt query = %q, want %q", query, BuildQuery(params))
+	}
+}
+
+func TestParseSearchURLRejectsOtherURLs(t *testing.T) {
+	for _, searchURL := range []string{"https://example.org/?page=2", "https://example.org/?q=x&publishyear_min%5B%5D=abc"} {
+		if _, err := ParseSearchURL(searchURL); err == nil {
+			t.Errorf("ParseSearchURL(%q) succeeded, want an error", searchURL)
+		}
+	}
+}
+
+func TestBuildQueryGolden(t *testing.T) {
+	// Every filter set, with accented languages and areas and a year range
+	params := validParams("soil carbon")
+	params.Source = "scielo"
+	params.AccessType = "sim"
+	params.PublicationTypes = []string{"Artigo", "Capítulo de livro"}
+	params.YearMin = 2015
+	params.YearMax = 2020
+	params.EffectiveYearMax = 2020
+	params.PeerReviewed = "sim"
+	params.Languages = []string{"Português", "Español", "Inglês"}
+	params.SubjectAreas = []string{"Ciências Agrárias", "Ecologia"}
+	params.SortOrder = "date_desc"
+
+	got := BuildQuery(params) + "\n"
+	golden := filepath.Join("testdata", "full_query.golden")
+	if *update {
+		if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("reading %s: %v (run with -update to create it)", golden, err)
+	}
+	if got != string(want) {
+		t.Errorf("BuildQuery() changed; CAPES depends on the exact order\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestBuildQueryYearRange(t *testing.T) {
+	tests := []struct {
+		min, max int
+		want     string
+	}{
+		{2015, 2020, "q=soil&source=&publishyear_min%5B%5D=2015&publishyear_max%5B%5D=2020"},
+		{2015, 0, "q=soil&source=&publishyear_min%5B%5D=2015"},
+		{0, 2020, "q=soil&source=&publishyear_max%5B%5D=2020"},
+	}
+
+	for _, tt := range tests {
+		params := validParams("soil")
+		params.YearMin = tt.min
+		params.EffectiveYearMax = tt.max
+		if got := BuildQuery(params); got != tt.want {
+			t.Errorf("BuildQuery(years %d-%d) = %q, want %q", tt.min, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestBuildLanguageParam(t *testing.T) {
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"Português", "language%5B%5D=language%3D%3DPortugu%C3%AAs"},
+		{"Español", "language%5B%5D=language%3D%3DEspa%C3%B1ol"},
+		{"Français", "language%5B%5D=language%3D%3DFran%C3%A7ais"},
+		{"Inglês", "language%5B%5D=language%3D%3DIngl%C3%AAs"},
+		{"Latim", "language%5B%5D=language%3D%3DLatim"},
+	}
+
+	for _, tt := range tests {
+		if got := buildLanguageParam(tt.lang); got != tt.want {
+			t.Errorf("buildLanguageParam(%q) = %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Package textnorm provides the accent- and case-insensitive text transform
+// used wherever user input is compared with text from CAPES
+package textnorm
+
+import (
+	"strings"
+	"unicode"
+)
+
+// foldGroups maps the accented Latin letters (Latin-1 Supplement and Latin
+// Extended-A) to their base letters; the standard library has no Unicode
+// decomposition, so the table stands in for NFD plus mark removal
+var foldGroups = map[string]string{
+	"ÀÁÂÃÄÅĀĂĄ":  "A",
+	"àáâãäåāăą":  "a",
+	"ÇĆĈĊČ":      "C",
+	"çćĉċč":      "c",
+	"ÐĎĐ":        "D",
+	"ðďđ":        "d",
+	"ÈÉÊËĒĔĖĘĚ":  "E",
+	"èéêëēĕėęě":  "e",
+	"ĜĞĠĢ":       "G",
+	"ĝğġģ":       "g",
+	"ĤĦ":         "H",
+	"ĥħ":         "h",
+	"ÌÍÎÏĨĪĬĮİ":  "I",
+	"ìíîïĩīĭįı":  "i",
+	"Ĵ":          "J",
+	"ĵ":          "j",
+	"Ķ":          "K",
+	"ķ":          "k",
+	"ĹĻĽĿŁ":      "L",
+	"ĺļľŀł":      "l",
+	"ÑŃŅŇ":       "N",
+	"ñńņň":       "n",
+	"ÒÓÔÕÖØŌŎŐ":  "O",
+	"òóôõöøōŏő":  "o",
+	"ŔŖŘ":        "R",
+	"ŕŗř":        "r",
+	"ŚŜŞŠ":       "S",
+	"śŝşš":       "s",
+	"ŢŤŦ":        "T",
+	"ţťŧ":        "t",
+	"ÙÚÛÜŨŪŬŮŰŲ": "U",
+	"ùúûüũūŭůűų": "u",
+	"Ŵ":          "W",
+	"ŵ":          "w",
+	"ÝŶŸ":        "Y",
+	"ýÿŷ":        "y",
+	"ŹŻŽ":        "Z",
+	"źżž":        "z",
+	"Æ":          "AE",
+	"æ":          "ae",
+	"Œ":          "OE",
+	"œ":          "oe",
+	"ß":          "ss",
+	"Þ":          "Th",
+	"þ":          "th",
+}
+
+// foldTable is foldGroups indexed by rune
+var foldTable = buildFoldTable()
+
+// buildFoldTable expands foldGroups into a rune lookup table
+func buildFoldTable() map[rune]string {
+	table := make(map[rune]string)
+	for letters, base := range foldGroups {
+		for _, r := range letters {
+			table[r] = base
+		}
+	}
+	return table
+}
+
+// StripAccents replaces accented letters with their base letters ("José"
+// becomes "Jose"), keeping the case. Combining marks are dropped as well, so
+// text already in decomposed form ("e" + U+0301) folds the same way
+func StripAccents(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+
+	for _, r := range s {
+		if unicode.Is(unicode.Mn, r) {
+			continue // Combining mark of a decomposed letter
+		}
+		if base, ok := foldTable[r]; ok {
+			sb.WriteString(base)
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// Normalize reduces s to the form used for loose comparisons: no accents,
+// lowercase and single spaces, without leading or trailing space
+// "  José  da Silva " and "jose da silva" normalize to the same text
+func Normalize(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(StripAccents(s))), " ")
+}
@@ -0,0 +1,84 @@
+// Package version identifies the build of the tool, so exported searches can
+// be traced back to the exact code that produced them
+package version
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// Build information, set at build time with -ldflags, for example:
+//
+//	go build -ldflags "-X github.com/alexandreffaria/reviu/internal/version.Version=v1.4.0
+//	  -X github.com/alexandreffaria/reviu/internal/version.Commit=$(git rev-parse --short HEAD)
+//	  -X github.com/alexandreffaria/reviu/internal/version.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Values left unset fall back to what the Go toolchain embeds from git
+var (
+	Version = ""
+	Commit  = ""
+	Date    = ""
+)
+
+// unknown stands in for build information that isn't available
+const unknown = "unknown"
+
+// Info describes the running build
+type Info struct {
+	Version string
+	Commit  string
+	Date    string
+}
+
+// Get returns the build information, filling fields not set with -ldflags
+// from the module version and VCS data recorded by the Go toolchain
+func Get() Info {
+	info := Info{Version: Version, Commit: Commit, Date: Date}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		if info.Version == "" && build.Main.Version != "" && build.Main.Version != "(devel)" {
+			info.Version = build.Main.Version
+		}
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = shortCommit(setting.Value)
+				}
+			case "vcs.time":
+				if info.Date == "" {
+					info.Date = setting.Value
+				}
+			case "vcs.modified":
+				if setting.Value == "true" && Commit == "" && info.Commit != "" {
+					info.Commit += "-dirty"
+				}
+			}
+		}
+	}
+
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	if info.Commit == "" {
+		info.Commit = unknown
+	}
+	if info.Date == "" {
+		info.Date = unknown
+	}
+	return info
+}
+
+// String returns the build information on one line, as printed by -version
+func String() string {
+	info := Get()
+	return fmt.Sprintf("capes-search %s (commit %s, built %s)", info.Version, info.Commit, info.Date)
+}
+
+// shortCommit abbreviates a full commit hash the way git does by default
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}